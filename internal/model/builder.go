@@ -0,0 +1,178 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// verseBuilder holds the book/chapter/verse being filled by a streaming importer (USFM, OSIS).
+// Importers feed it text and structure events; it takes care of whitespace, pending
+// headings and paragraphs, and words-of-Jesus spans that cross verse boundaries.
+type verseBuilder struct {
+	book    *Book
+	chapter *Chapter
+	verse   *Verse
+
+	heading   string // Pending section heading for the next verse
+	paragraph bool   // A paragraph break is waiting for the next verse
+	wjStart   int    // Start of the open words-of-Jesus span, -1 when closed
+}
+
+func newVerseBuilder() verseBuilder {
+	return verseBuilder{wjStart: -1}
+}
+
+func (b *verseBuilder) startChapter(num int) {
+	b.finishVerse()
+	b.chapter = &Chapter{Number: num}
+	b.book.Chapters = append(b.book.Chapters, b.chapter)
+}
+
+// startVerse opens verse num, or the bridge num-through when through is past it.
+func (b *verseBuilder) startVerse(num, through int) {
+	b.finishVerse()
+	b.verse = &Verse{Number: num, Heading: b.heading, Paragraph: b.paragraph}
+	if through > num {
+		b.verse.Through = through
+	}
+	b.heading, b.paragraph = "", false
+	if b.wjStart >= 0 {
+		b.wjStart = 0 // A words-of-Jesus span continues into the new verse
+	}
+}
+
+func (b *verseBuilder) finishVerse() {
+	if b.verse == nil {
+		return
+	}
+	if b.wjStart >= 0 {
+		b.addSpan("wj", b.wjStart)
+	}
+	b.verse.Text = strings.TrimSpace(b.verse.Text)
+	for i := range b.verse.Notes {
+		b.verse.Notes[i].Offset = min(b.verse.Notes[i].Offset, len(b.verse.Text))
+	}
+	b.chapter.Verses = append(b.chapter.Verses, b.verse)
+	b.verse = nil
+}
+
+func (b *verseBuilder) addHeading(text string) {
+	if text = collapseSpaces(strings.TrimSpace(text)); text != "" {
+		b.heading = strings.TrimSpace(b.heading + " " + text)
+	}
+}
+
+// markParagraph records a paragraph break; it only sticks if the next thing is a new verse.
+func (b *verseBuilder) markParagraph() {
+	b.paragraph = true
+	b.space()
+}
+
+// appendText adds text to the open verse, collapsing whitespace. Text outside verses
+// (introductions, titles) is dropped.
+func (b *verseBuilder) appendText(s string) {
+	if b.verse == nil {
+		return
+	}
+	s = collapseSpaces(s)
+	if b.verse.Text == "" || strings.HasSuffix(b.verse.Text, " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	if b.wjStart == len(b.verse.Text) && strings.HasPrefix(s, " ") {
+		b.wjStart++ // The span starts after the separating space
+	}
+	b.verse.Text += s
+	if strings.TrimSpace(s) != "" {
+		b.paragraph = false // The paragraph started mid-verse
+	}
+}
+
+func (b *verseBuilder) space() {
+	b.appendText(" ")
+}
+
+// noteOffset is where a note starting now is anchored.
+func (b *verseBuilder) noteOffset() int {
+	if b.verse == nil {
+		return 0
+	}
+	return len(strings.TrimRight(b.verse.Text, " "))
+}
+
+func (b *verseBuilder) addNote(n Note) {
+	n.Text = collapseSpaces(strings.TrimSpace(n.Text))
+	if b.verse != nil && n.Text != "" {
+		b.verse.Notes = append(b.verse.Notes, n)
+	}
+}
+
+func (b *verseBuilder) openWordsOfJesus() {
+	b.wjStart = 0
+	if b.verse != nil {
+		b.wjStart = len(b.verse.Text)
+	}
+}
+
+func (b *verseBuilder) closeWordsOfJesus() {
+	if b.wjStart >= 0 {
+		b.addSpan("wj", b.wjStart)
+		b.wjStart = -1
+	}
+}
+
+func (b *verseBuilder) addSpan(kind string, start int) {
+	if b.verse == nil {
+		return
+	}
+	end := len(strings.TrimRight(b.verse.Text, " "))
+	if start < end {
+		b.verse.Spans = append(b.verse.Spans, Span{Kind: kind, Start: start, End: end})
+	}
+}
+
+func firstWord(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// verseNumbers reads a verse number, or the first and last numbers of a bridge ("2-3"). Letters
+// after a number ("2a") are ignored; through is 0 for a single verse.
+func verseNumbers(s string) (num, through int, err error) {
+	first, last, bridged := strings.Cut(s, "-")
+	if num, err = strconv.Atoi(leadingDigits(first)); err != nil || !bridged {
+		return num, 0, err
+	}
+	through, err = strconv.Atoi(leadingDigits(last))
+	return num, through, err
+}
+
+func leadingDigits(s string) string {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	return s[:end]
+}
+
+func collapseSpaces(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
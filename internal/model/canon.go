@@ -0,0 +1,198 @@
+package model
+
+import "strings"
+
+// BookInfo describes a canonical book independently of any translation.
+type BookInfo struct {
+	OSIS       string
+	Name       string
+	FullName   string
+	Collection string
+	Aliases    []string
+}
+
+// CollectionInfo describes a top-level grouping of books, the root level of the shell.
+type CollectionInfo struct {
+	ID   string
+	Name string
+	Of   string // Collection whose canonical books this one holds under another name ("AP" holds the "DC" books)
+}
+
+// Collections lists the known collections in reading order. Data may use others; they follow these.
+var Collections = []CollectionInfo{
+	{"OT", "Old Testament", ""},
+	{"DC", "Deuterocanonical Books", ""},
+	{"AP", "Apocrypha", "DC"},
+	{"NT", "New Testament", ""},
+}
+
+// LookupCollection finds a known collection by ID or name and returns its reading-order position.
+func LookupCollection(name string) (CollectionInfo, int, bool) {
+	for i, c := range Collections {
+		if NormalizeName(c.ID) == NormalizeName(name) || NormalizeName(c.Name) == NormalizeName(name) {
+			return c, i, true
+		}
+	}
+	return CollectionInfo{}, -1, false
+}
+
+// SameCollection reports whether two collection IDs hold the same canonical books ("DC" and "AP").
+func SameCollection(a, b string) bool {
+	canonical := func(id string) string {
+		if c, _, ok := LookupCollection(id); ok && c.Of != "" {
+			return c.Of
+		}
+		return strings.ToUpper(id)
+	}
+	return canonical(a) == canonical(b)
+}
+
+// protestantBooks is how many books the numbered formats (Zefania, CSV) count: Genesis is 1, Revelation 66.
+const protestantBooks = 66
+
+// Canon lists the books of the Protestant canon in their traditional order, followed by the
+// deuterocanonical books. Sorting happens within a collection, so the DC books can sit at the end.
+var Canon = []BookInfo{
+	// --- OLD TESTAMENT ---
+	{"Gen", "Genesis", "The First Book of Moses, called Genesis", "OT", nil},
+	{"Exod", "Exodus", "The Second Book of Moses, called Exodus", "OT", nil},
+	{"Lev", "Leviticus", "The Third Book of Moses, called Leviticus", "OT", nil},
+	{"Num", "Numbers", "The Fourth Book of Moses, called Numbers", "OT", nil},
+	{"Deut", "Deuteronomy", "The Fifth Book of Moses, called Deuteronomy", "OT", nil},
+	{"Josh", "Joshua", "The Book of Joshua", "OT", nil},
+	{"Judg", "Judges", "The Book of Judges", "OT", nil},
+	{"Ruth", "Ruth", "The Book of Ruth", "OT", nil},
+	{"1Sam", "1 Samuel", "The First Book of Samuel", "OT", []string{"I Samuel"}},
+	{"2Sam", "2 Samuel", "The Second Book of Samuel", "OT", []string{"II Samuel"}},
+	{"1Kgs", "1 Kings", "The First Book of the Kings", "OT", []string{"I Kings"}},
+	{"2Kgs", "2 Kings", "The Second Book of the Kings", "OT", []string{"II Kings"}},
+	{"1Chr", "1 Chronicles", "The First Book of the Chronicles", "OT", []string{"I Chronicles"}},
+	{"2Chr", "2 Chronicles", "The Second Book of the Chronicles", "OT", []string{"II Chronicles"}},
+	{"Ezra", "Ezra", "The Book of Ezra", "OT", nil},
+	{"Neh", "Nehemiah", "The Book of Nehemiah", "OT", nil},
+	{"Esth", "Esther", "The Book of Esther", "OT", nil},
+	{"Job", "Job", "The Book of Job", "OT", nil},
+	{"Ps", "Psalms", "The Book of Psalms", "OT", []string{"Psalm"}},
+	{"Prov", "Proverbs", "The Proverbs", "OT", nil},
+	{"Eccl", "Ecclesiastes", "Ecclesiastes, or the Preacher", "OT", []string{"Qoheleth"}},
+	{"Song", "Song of Solomon", "The Song of Solomon", "OT", []string{"Song of Songs", "Canticles"}},
+	{"Isa", "Isaiah", "The Book of the Prophet Isaiah", "OT", nil},
+	{"Jer", "Jeremiah", "The Book of the Prophet Jeremiah", "OT", nil},
+	{"Lam", "Lamentations", "The Lamentations of Jeremiah", "OT", nil},
+	{"Ezek", "Ezekiel", "The Book of the Prophet Ezekiel", "OT", nil},
+	{"Dan", "Daniel", "The Book of Daniel", "OT", nil},
+	{"Hos", "Hosea", "The Book of Hosea", "OT", nil},
+	{"Joel", "Joel", "The Book of Joel", "OT", nil},
+	{"Amos", "Amos", "The Book of Amos", "OT", nil},
+	{"Obad", "Obadiah", "The Book of Obadiah", "OT", nil},
+	{"Jonah", "Jonah", "The Book of Jonah", "OT", nil},
+	{"Mic", "Micah", "The Book of Micah", "OT", nil},
+	{"Nah", "Nahum", "The Book of Nahum", "OT", nil},
+	{"Hab", "Habakkuk", "The Book of Habakkuk", "OT", nil},
+	{"Zeph", "Zephaniah", "The Book of Zephaniah", "OT", nil},
+	{"Hag", "Haggai", "The Book of Haggai", "OT", nil},
+	{"Zech", "Zechariah", "The Book of Zechariah", "OT", nil},
+	{"Mal", "Malachi", "The Book of Malachi", "OT", nil},
+
+	// --- NEW TESTAMENT ---
+	{"Matt", "Matthew", "The Gospel According to Matthew", "NT", nil},
+	{"Mark", "Mark", "The Gospel According to Mark", "NT", nil},
+	{"Luke", "Luke", "The Gospel According to Luke", "NT", nil},
+	{"John", "John", "The Gospel According to John", "NT", nil},
+	{"Acts", "Acts", "The Acts of the Apostles", "NT", []string{"Acts of the Apostles"}},
+	{"Rom", "Romans", "The Epistle of Paul to the Romans", "NT", nil},
+	{"1Cor", "1 Corinthians", "The First Epistle of Paul to the Corinthians", "NT", []string{"I Corinthians"}},
+	{"2Cor", "2 Corinthians", "The Second Epistle of Paul to the Corinthians", "NT", []string{"II Corinthians"}},
+	{"Gal", "Galatians", "The Epistle of Paul to the Galatians", "NT", nil},
+	{"Eph", "Ephesians", "The Epistle of Paul to the Ephesians", "NT", nil},
+	{"Phil", "Philippians", "The Epistle of Paul to the Philippians", "NT", nil},
+	{"Col", "Colossians", "The Epistle of Paul to the Colossians", "NT", nil},
+	{"1Thess", "1 Thessalonians", "The First Epistle of Paul to the Thessalonians", "NT", []string{"I Thessalonians"}},
+	{"2Thess", "2 Thessalonians", "The Second Epistle of Paul to the Thessalonians", "NT", []string{"II Thessalonians"}},
+	{"1Tim", "1 Timothy", "The First Epistle of Paul to Timothy", "NT", []string{"I Timothy"}},
+	{"2Tim", "2 Timothy", "The Second Epistle of Paul to Timothy", "NT", []string{"II Timothy"}},
+	{"Titus", "Titus", "The Epistle of Paul to Titus", "NT", nil},
+	{"Phlm", "Philemon", "The Epistle of Paul to Philemon", "NT", nil},
+	{"Heb", "Hebrews", "The Epistle to the Hebrews", "NT", nil},
+	{"Jas", "James", "The General Epistle of James", "NT", nil},
+	{"1Pet", "1 Peter", "The First Epistle General of Peter", "NT", []string{"I Peter"}},
+	{"2Pet", "2 Peter", "The Second Epistle General of Peter", "NT", []string{"II Peter"}},
+	{"1John", "1 John", "The First Epistle General of John", "NT", []string{"I John"}},
+	{"2John", "2 John", "The Second Epistle of John", "NT", []string{"II John"}},
+	{"3John", "3 John", "The Third Epistle of John", "NT", []string{"III John"}},
+	{"Jude", "Jude", "The General Epistle of Jude", "NT", nil},
+	{"Rev", "Revelation", "The Revelation of Jesus Christ", "NT", []string{"Revelations", "Revelation of John", "Apocalypse"}},
+
+	// --- DEUTEROCANONICAL BOOKS ---
+	{"Tob", "Tobit", "The Book of Tobit", "DC", []string{"Tobias"}},
+	{"Jdt", "Judith", "The Book of Judith", "DC", nil},
+	{"AddEsth", "Additions to Esther", "The Rest of the Chapters of the Book of Esther", "DC", []string{"Esther (Greek)", "Greek Esther", "EsthGr"}},
+	{"Wis", "Wisdom", "The Wisdom of Solomon", "DC", []string{"Wisdom of Solomon"}},
+	{"Sir", "Sirach", "The Wisdom of Jesus the Son of Sirach, or Ecclesiasticus", "DC", []string{"Ecclesiasticus", "Ben Sira"}},
+	{"Bar", "Baruch", "The Book of Baruch", "DC", nil},
+	{"EpJer", "Letter of Jeremiah", "The Epistle of Jeremiah", "DC", []string{"Epistle of Jeremiah", "Epistle of Jeremy"}},
+	{"PrAzar", "Prayer of Azariah", "The Prayer of Azariah and the Song of the Three Holy Children", "DC", []string{"Song of the Three Children", "Song of Three Youths"}},
+	{"Sus", "Susanna", "The History of Susanna", "DC", nil},
+	{"Bel", "Bel and the Dragon", "The History of the Destruction of Bel and the Dragon", "DC", nil},
+	{"1Macc", "1 Maccabees", "The First Book of the Maccabees", "DC", []string{"I Maccabees"}},
+	{"2Macc", "2 Maccabees", "The Second Book of the Maccabees", "DC", []string{"II Maccabees"}},
+	{"1Esd", "1 Esdras", "The First Book of Esdras", "DC", []string{"I Esdras"}},
+	{"2Esd", "2 Esdras", "The Second Book of Esdras", "DC", []string{"II Esdras"}},
+	{"PrMan", "Prayer of Manasseh", "The Prayer of Manasses", "DC", []string{"Prayer of Manasses"}},
+	{"3Macc", "3 Maccabees", "The Third Book of the Maccabees", "DC", []string{"III Maccabees"}},
+	{"4Macc", "4 Maccabees", "The Fourth Book of the Maccabees", "DC", []string{"IV Maccabees"}},
+	{"AddPs", "Psalm 151", "Psalm 151", "DC", nil},
+}
+
+// numberedBook returns book n (1-66) of the Protestant numbering used by Zefania and CSV files.
+func numberedBook(n int) (BookInfo, bool) {
+	if n < 1 || n > protestantBooks {
+		return BookInfo{}, false
+	}
+	return Canon[n-1], true
+}
+
+// canonIndex maps normalized names, aliases and OSIS IDs to a position in Canon.
+var canonIndex = buildCanonIndex()
+
+func buildCanonIndex() map[string]int {
+	idx := make(map[string]int)
+	for i, info := range Canon {
+		idx[NormalizeName(info.Name)] = i
+		idx[NormalizeName(info.OSIS)] = i
+		for _, alias := range info.Aliases {
+			idx[NormalizeName(alias)] = i
+		}
+	}
+	return idx
+}
+
+// LookupCanon finds a book by name, alias or OSIS ID, or by its name in one of the built-in
+// languages ("Genèse", "Johannes"), and returns its canonical position.
+func LookupCanon(name string) (BookInfo, int, bool) {
+	key := NormalizeName(name)
+	i, ok := canonIndex[key]
+	if !ok {
+		i, ok = localIndex[key]
+	}
+	if !ok {
+		return BookInfo{}, -1, false
+	}
+	return Canon[i], i, true
+}
+
+// accentFolder spells accented Latin letters without their marks, so "Genese" finds "Genèse".
+var accentFolder = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ý", "y", "ÿ", "y",
+	" ", "", ".", "",
+)
+
+// NormalizeName folds case and accents and drops spaces and dots so "1 John", "1john", "1 JOHN"
+// and "Génesis", "genesis" compare equal.
+func NormalizeName(s string) string {
+	return accentFolder.Replace(strings.ToLower(s))
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseCSV reads a flat "book,chapter,verse,text" table (comma- or tab-separated, as
+// exported from a spreadsheet). An optional header row is skipped. The book column may hold
+// a name in any of the built-in languages, an OSIS ID or a canonical book number (1-66). Books
+// outside the canon take the collection of the book before them and follow its canonical books,
+// in file order. A verse given twice is an error.
+func ParseCSV(data []byte, sep rune) (*Bible, error) {
+	return parseCSV(data, sep, nil)
+}
+
+// parseCSV is ParseCSV, but with issues given it reports bad and repeated rows there and skips them.
+func parseCSV(data []byte, sep rune, issues *Report) (*Bible, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = sep
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+
+	var books []*Book
+	byName := make(map[string]*Book)
+	byOSIS := make(map[string]*Book)
+	type verseKey struct {
+		book           *Book
+		chapter, verse int
+	}
+	seen := make(map[verseKey]int) // Line each verse was read from
+	collection := "OT"
+
+	for line := 1; ; line++ {
+		rec, err := r.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		if len(rec) == 1 && strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+		if len(rec) < 4 {
+			if err := rowError(issues, line, "expected book, chapter, verse and text, got %d columns", len(rec)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		bookName, cKey, vKey := strings.TrimSpace(rec[0]), strings.TrimSpace(rec[1]), strings.TrimSpace(rec[2])
+		text := strings.TrimSpace(strings.Join(rec[3:], string(sep)))
+		cNum, errC := strconv.Atoi(cKey)
+		vNum, through, errV := verseNumbers(vKey)
+		if line == 1 && (errC != nil || errV != nil) {
+			continue // Header row
+		}
+		if errC != nil || errV != nil {
+			msg, key := "chapter %q is not a number", cKey
+			if errC == nil {
+				msg, key = "verse %q is not a number", vKey
+			}
+			if err := rowError(issues, line, msg, key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		book, ok := byName[bookName]
+		if !ok {
+			book = csvBook(bookName)
+			if known, ok := byOSIS[book.OSIS]; ok && book.OSIS != "" {
+				book = known // Another spelling of a book already read ("John", "Jn")
+			} else {
+				if book.Collection == "" {
+					book.Collection = collection
+				}
+				collection = book.Collection
+				byOSIS[book.OSIS] = book
+				books = append(books, book)
+			}
+			byName[bookName] = book
+		}
+
+		ref := verseKey{book, cNum, vNum}
+		if first, ok := seen[ref]; ok {
+			if err := rowError(issues, line, "%s %d:%d is repeated (first on line %d)", book.Name, cNum, vNum, first); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		seen[ref] = line
+
+		ch := book.Chapter(cNum)
+		if ch == nil {
+			ch = &Chapter{Number: cNum}
+			book.Chapters = append(book.Chapters, ch)
+		}
+		v := &Verse{Number: vNum, Text: text}
+		if through > vNum {
+			v.Through = through // A bridge: "2-3"
+		}
+		ch.Verses = append(ch.Verses, v)
+	}
+
+	if len(books) == 0 {
+		return nil, fmt.Errorf("no verses found")
+	}
+	return assemble(books), nil
+}
+
+// rowError is the error for a bad row, or nil once it is reported to issues, if given, so
+// that the row can be skipped.
+func rowError(issues *Report, line int, format string, args ...any) error {
+	if issues == nil {
+		return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+	issues.add(SeverityError, fmt.Sprintf("line %d", line), format, args...)
+	return nil
+}
+
+// csvBook resolves the book column: a canonical number, a known name/OSIS ID, or a custom name.
+func csvBook(name string) *Book {
+	if num, err := strconv.Atoi(name); err == nil {
+		if info, ok := numberedBook(num); ok {
+			return newBook("", info.OSIS)
+		}
+	}
+	if info, ok := LookupBookName(name, BuiltinNames); ok {
+		return newBook(name, info.OSIS)
+	}
+	return newBook(name, "")
+}
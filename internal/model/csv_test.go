@@ -0,0 +1,84 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	src := "book,chapter,verse,text\n" +
+		"John,3,17,\"For God sent not his Son into the world, to condemn the world.\"\n" +
+		"John,3,16,For God so loved the world\n" +
+		"1,1,1,In the beginning God created the heaven and the earth.\n"
+	db, err := ParseCSV([]byte(src), ',')
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if books := db.Books(); len(books) != 2 || books[0].Name != "Genesis" || books[1].Collection != "NT" {
+		t.Fatalf("Book numbers and names should resolve against the canon: %+v", books)
+	}
+	ch := db.Book("John").Chapter(3)
+	if ch.Verses[0].Number != 16 || ch.Verse(17).Text != "For God sent not his Son into the world, to condemn the world." {
+		t.Errorf("Verses should be sorted and quoted commas kept: %+v", ch.Verses)
+	}
+}
+
+func TestParseTSVCustomBooks(t *testing.T) {
+	// Books unknown to the canon keep their file order and follow the canonical books of the
+	// collection before them
+	src := "Juan\t1\t1\tEn el principio era el Verbo\nMateus\t1\t1\tLivro da geração\n" +
+		"Apocalipsis\t1\t1\tLa revelación\nJoão\t1\t1\tNo princípio era o Verbo\n"
+	db, err := ParseCSV([]byte(src), '\t')
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	var names []string
+	for _, b := range db.Books() {
+		names = append(names, b.Name+"/"+b.Collection)
+	}
+	if got := strings.Join(names, ", "); got != "Juan/NT, Apocalipsis/NT, Mateus/NT, João/NT" {
+		t.Errorf("Custom books should follow the canon in file order, got %s", got)
+	}
+}
+
+func TestParseCSVLocalNames(t *testing.T) {
+	// Abbreviations of the built-in name tables resolve like canonical names
+	db, err := ParseCSV([]byte("Jn,3,16,Porque de tal manera amó Dios al mundo\n"), ',')
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if b := db.Books()[0]; b.Collection != "NT" || b.FullName != "The Gospel According to John" {
+		t.Errorf("Local abbreviation should resolve against the canon: %+v", b)
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := map[string]string{
+		"Non-numeric chapter": "John,3,16,a\nJohn,x,17,b\n",
+		"Missing columns":     "John,3,16\n",
+		"No verses":           "book,chapter,verse,text\n",
+		"Repeated verse":      "John,3,16,a\nJn,3,16,b\n",
+	}
+	for name, src := range tests {
+		if _, err := ParseCSV([]byte(src), ','); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestRegistryFormatOverride(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "mine.txt")
+	os.WriteFile(file, []byte("John\t3\t16\tFor God so loved the world\n"), 0644)
+
+	r := NewRegistry()
+	r.Format = FormatTSV
+	if err := r.LoadPath(file); err != nil {
+		t.Fatalf("Forced TSV should load: %v", err)
+	}
+	if b := r.Get("mine"); b == nil || b.Book("John").Chapter(3).Verse(16) == nil {
+		t.Error("Translation should be named after the file")
+	}
+}
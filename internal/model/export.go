@@ -0,0 +1,336 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Export-only formats.
+const (
+	FormatMarkdown = "markdown"
+	FormatPlain    = "plain"
+)
+
+// ExportFormats lists the formats Export can write. USFM writes a directory (see ExportUSFM).
+var ExportFormats = []string{FormatJSON, FormatCSV, FormatTSV, FormatUSFM, FormatOSIS, FormatMarkdown, FormatPlain}
+
+// ExportExtension returns the usual file extension for an export format.
+func ExportExtension(format string) string {
+	switch format {
+	case FormatOSIS:
+		return ".xml"
+	case FormatMarkdown:
+		return ".md"
+	case FormatPlain:
+		return ".txt"
+	case FormatUSFM:
+		return ""
+	}
+	return "." + format
+}
+
+// Export writes a Bible to w in a single-file format.
+func Export(w io.Writer, db *Bible, format string) error {
+	bw := bufio.NewWriter(w)
+	var err error
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		var data []byte
+		if data, err = json.Marshal(db); err == nil {
+			_, err = bw.Write(data)
+		}
+	case FormatCSV:
+		err = exportCSV(bw, db, ',')
+	case FormatTSV:
+		err = exportCSV(bw, db, '\t')
+	case FormatOSIS:
+		exportOSIS(bw, db)
+	case FormatMarkdown:
+		exportMarkdown(bw, db)
+	case FormatPlain:
+		exportPlain(bw, db)
+	case FormatUSFM:
+		return fmt.Errorf("USFM is written as one file per book; export it to a directory")
+	default:
+		return fmt.Errorf("unknown format '%s' (supported: %s)", format, strings.Join(ExportFormats, ", "))
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ExportUSFM writes one USFM file per book into dir, creating it if needed.
+func ExportUSFM(dir string, db *Bible) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for i, book := range db.Books() {
+		code := usfmCode(book)
+		var buf bytes.Buffer
+		writeUSFMBook(&buf, book, code)
+		path := filepath.Join(dir, fmt.Sprintf("%02d-%s.usfm", i+1, code))
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Filter returns a copy of the Bible holding only the verses keep accepts. Chapters, books
+// and collections left empty are dropped; verses themselves are shared, not copied.
+func (b *Bible) Filter(keep func(book *Book, ch *Chapter, v *Verse) bool) *Bible {
+	out := &Bible{ID: b.ID, Name: b.Name, Language: b.Language, Versification: b.Versification}
+	for _, t := range b.Collections {
+		nt := &Collection{ID: t.ID, Name: t.Name}
+		for _, book := range t.Books {
+			nb := *book
+			nb.Chapters = nil
+			for _, ch := range book.Chapters {
+				nc := &Chapter{Number: ch.Number}
+				for _, v := range ch.Verses {
+					if keep(book, ch, v) {
+						nc.Verses = append(nc.Verses, v)
+					}
+				}
+				if len(nc.Verses) > 0 {
+					nb.Chapters = append(nb.Chapters, nc)
+				}
+			}
+			if len(nb.Chapters) > 0 {
+				nt.Books = append(nt.Books, &nb)
+			}
+		}
+		if len(nt.Books) > 0 {
+			out.Collections = append(out.Collections, nt)
+		}
+	}
+	return out
+}
+
+// --- WRITERS ---
+
+func exportCSV(w io.Writer, db *Bible, sep rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = sep
+	cw.Write([]string{"book", "chapter", "verse", "text"})
+	for _, book := range db.Books() {
+		for _, ch := range book.Chapters {
+			for _, v := range ch.Verses {
+				cw.Write([]string{book.Name, strconv.Itoa(ch.Number), v.Label(), v.Text})
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func exportPlain(w io.Writer, db *Bible) {
+	for _, book := range db.Books() {
+		for _, ch := range book.Chapters {
+			for _, v := range ch.Verses {
+				fmt.Fprintf(w, "%s %d:%s %s\n", book.Name, ch.Number, v.Label(), v.Text)
+			}
+		}
+	}
+}
+
+func exportMarkdown(w io.Writer, db *Bible) {
+	fmt.Fprintf(w, "# %s\n", db.Name)
+	for _, book := range db.Books() {
+		fmt.Fprintf(w, "\n## %s\n", book.Name)
+		for _, ch := range book.Chapters {
+			fmt.Fprintf(w, "\n### %s %d\n\n", book.Name, ch.Number)
+			var notes []string
+			for i, v := range ch.Verses {
+				if v.Heading != "" {
+					if i > 0 {
+						fmt.Fprint(w, "\n\n")
+					}
+					fmt.Fprintf(w, "#### %s\n\n", v.Heading)
+				} else if v.Paragraph && i > 0 {
+					fmt.Fprint(w, "\n\n")
+				} else if i > 0 {
+					fmt.Fprint(w, " ")
+				}
+				text := verseMarkup{escape: plainText, note: func(n Note) string {
+					notes = append(notes, n.Text)
+					return fmt.Sprintf("[^%d-%d]", ch.Number, len(notes))
+				}}.render(v)
+				fmt.Fprintf(w, "**%s** %s", v.Label(), text)
+			}
+			fmt.Fprintln(w)
+			if len(notes) > 0 {
+				fmt.Fprintln(w)
+				for i, n := range notes {
+					fmt.Fprintf(w, "[^%d-%d]: %s\n", ch.Number, i+1, strings.TrimSpace(n))
+				}
+			}
+		}
+	}
+}
+
+func exportOSIS(w io.Writer, db *Bible) {
+	work := strings.ToUpper(db.ID)
+	fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(w, `<osis xmlns="http://www.bibletechnologies.net/2003/OSIS/namespace">`)
+	fmt.Fprintf(w, "<osisText osisIDWork=\"%s\" osisRefWork=\"Bible\" xml:lang=\"%s\">\n", xmlEscape(work), xmlEscape(db.Language))
+	fmt.Fprintf(w, "<header><work osisWork=\"%s\"><title>%s</title></work></header>\n", xmlEscape(work), xmlEscape(db.Name))
+
+	m := verseMarkup{
+		escape: xmlEscape,
+		open:   wordsOfJesus(`<q who="Jesus" marker="">`),
+		close:  wordsOfJesus("</q>"),
+		note: func(n Note) string {
+			caller := ""
+			if n.Caller != "" {
+				caller = fmt.Sprintf(` n="%s"`, xmlEscape(n.Caller))
+			}
+			return fmt.Sprintf("<note type=\"study\"%s>%s</note>", caller, xmlEscape(strings.TrimSpace(n.Text)))
+		},
+	}
+
+	for _, book := range db.Books() {
+		id := book.OSIS
+		if id == "" {
+			id = strings.ReplaceAll(book.Name, " ", "")
+		}
+		fmt.Fprintf(w, "<div type=\"book\" osisID=\"%s\">\n<title type=\"main\">%s</title>\n", xmlEscape(id), xmlEscape(book.FullName))
+		for _, ch := range book.Chapters {
+			fmt.Fprintf(w, "<chapter osisID=\"%s.%d\">\n", xmlEscape(id), ch.Number)
+			for _, v := range ch.Verses {
+				if v.Heading != "" {
+					fmt.Fprintf(w, "<title type=\"section\">%s</title>\n", xmlEscape(v.Heading))
+				}
+				if v.Paragraph {
+					fmt.Fprintln(w, `<milestone type="x-p" marker="¶"/>`)
+				}
+				ids := make([]string, 0, 1)
+				for n := v.Number; n <= max(v.Number, v.Through); n++ {
+					ids = append(ids, fmt.Sprintf("%s.%d.%d", xmlEscape(id), ch.Number, n)) // Bridges list every verse
+				}
+				text := m.render(v)
+				fmt.Fprintf(w, "<verse osisID=\"%s\">%s</verse>\n", strings.Join(ids, " "), text)
+			}
+			fmt.Fprintln(w, "</chapter>")
+		}
+		fmt.Fprintln(w, "</div>")
+	}
+	fmt.Fprintln(w, "</osisText>\n</osis>")
+}
+
+func writeUSFMBook(w io.Writer, book *Book, code string) {
+	fmt.Fprintf(w, "\\id %s\n\\h %s\n\\mt1 %s\n", code, book.Name, book.FullName)
+	m := verseMarkup{
+		escape: plainText,
+		open:   wordsOfJesus(`\wj `),
+		close:  wordsOfJesus(`\wj*`),
+		note: func(n Note) string {
+			caller := n.Caller
+			if caller == "" {
+				caller = "+"
+			}
+			return fmt.Sprintf(`\f %s \ft %s\f*`, caller, strings.TrimSpace(n.Text))
+		},
+	}
+
+	for _, ch := range book.Chapters {
+		fmt.Fprintf(w, "\\c %d\n", ch.Number)
+		for i, v := range ch.Verses {
+			if v.Heading != "" {
+				fmt.Fprintf(w, "\\s1 %s\n", v.Heading)
+			}
+			if v.Paragraph || v.Heading != "" || i == 0 {
+				fmt.Fprintln(w, `\p`)
+			}
+			text := m.render(v)
+			fmt.Fprintf(w, "\\v %s %s\n", v.Label(), text)
+		}
+	}
+}
+
+// usfmCode returns the USFM book code for a book, making one up for books outside the table.
+func usfmCode(book *Book) string {
+	for code, osis := range usfmBooks {
+		if osis == book.OSIS {
+			return code
+		}
+	}
+	code := strings.ToUpper(strings.ReplaceAll(book.Name, " ", ""))
+	if len(code) > 3 {
+		code = code[:3]
+	}
+	return code
+}
+
+// verseMarkup rebuilds a verse's text with its spans and notes inserted at their offsets.
+type verseMarkup struct {
+	escape      func(string) string // Applied to the plain text
+	open, close func(Span) string   // Optional
+	note        func(Note) string
+}
+
+func (m verseMarkup) render(v *Verse) string {
+	clamp := func(pos int) int { return min(max(pos, 0), len(v.Text)) }
+	cuts := []int{0, len(v.Text)}
+	for _, s := range v.Spans {
+		cuts = append(cuts, clamp(s.Start), clamp(s.End))
+	}
+	for _, n := range v.Notes {
+		cuts = append(cuts, clamp(n.Offset))
+	}
+	sort.Ints(cuts)
+	cuts = slices.Compact(cuts)
+
+	var sb strings.Builder
+	for i, pos := range cuts {
+		for _, s := range v.Spans {
+			if m.close != nil && s.End == pos && s.Start != s.End {
+				sb.WriteString(m.close(s))
+			}
+		}
+		for _, n := range v.Notes {
+			if clamp(n.Offset) == pos {
+				sb.WriteString(m.note(n))
+			}
+		}
+		for _, s := range v.Spans {
+			if m.open != nil && s.Start == pos && s.Start != s.End {
+				sb.WriteString(m.open(s))
+			}
+		}
+		if i+1 < len(cuts) {
+			sb.WriteString(m.escape(v.Text[pos:cuts[i+1]]))
+		}
+	}
+	return sb.String()
+}
+
+// wordsOfJesus renders tag for "wj" spans and nothing for other kinds.
+func wordsOfJesus(tag string) func(Span) string {
+	return func(s Span) string {
+		if s.Kind != "wj" {
+			return ""
+		}
+		return tag
+	}
+}
+
+func plainText(s string) string { return s }
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// studyBible carries every kind of verse data the writers have to keep.
+func studyBible(t *testing.T) *Bible {
+	db, err := ParseUSFM([]byte(sampleJohn), []byte(sampleGenesis))
+	if err != nil {
+		t.Fatalf("ParseUSFM failed: %v", err)
+	}
+	db.ID, db.Name, db.Language = "web", "World English Bible", "en"
+	return db
+}
+
+func TestExportRoundTrips(t *testing.T) {
+	db := studyBible(t)
+	for _, format := range []string{FormatJSON, FormatOSIS, FormatCSV, FormatTSV} {
+		var buf bytes.Buffer
+		if err := Export(&buf, db, format); err != nil {
+			t.Fatalf("%s: Export failed: %v", format, err)
+		}
+		back, err := Parse(buf.Bytes(), format)
+		if err != nil {
+			t.Fatalf("%s: exported text does not parse: %v\n%s", format, err, buf.String())
+		}
+		for _, book := range db.Books() {
+			for _, ch := range book.Chapters {
+				for _, v := range ch.Verses {
+					got := back.Book(book.Name).Chapter(ch.Number).Verse(v.Number)
+					want := *v
+					if format == FormatCSV || format == FormatTSV {
+						want = Verse{Number: v.Number, Through: v.Through, Text: v.Text} // Flat tables keep only the text and numbers
+					}
+					if got == nil || !reflect.DeepEqual(*got, want) {
+						t.Errorf("%s: %s %d:%d changed\n got: %+v\nwant: %+v", format, book.Name, ch.Number, v.Number, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestExportUSFM(t *testing.T) {
+	db := studyBible(t)
+	dir := filepath.Join(t.TempDir(), "usfm")
+	if err := ExportUSFM(dir, db); err != nil {
+		t.Fatalf("ExportUSFM failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "02-JHN.usfm")); err != nil {
+		t.Fatalf("Books should be written one file each in order: %v", err)
+	}
+	back, err := LoadUSFMDir(dir)
+	if err != nil {
+		t.Fatalf("Exported USFM does not load: %v", err)
+	}
+	for _, ref := range []VerseRef{{"John", 3, 16}, {"Genesis", 1, 2}} {
+		want := db.Book(ref.Book).Chapter(ref.Chapter).Verse(ref.Verse)
+		if got := back.Book(ref.Book).Chapter(ref.Chapter).Verse(ref.Verse); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s %d:%d changed\n got: %+v\nwant: %+v", ref.Book, ref.Chapter, ref.Verse, got, want)
+		}
+	}
+}
+
+func TestExportTextFormats(t *testing.T) {
+	db := studyBible(t).Filter(func(b *Book, c *Chapter, v *Verse) bool { return b.Name == "John" && v.Number == 16 })
+	if books := db.Books(); len(books) != 1 || len(books[0].Chapters) != 1 || len(db.Collections) != 1 {
+		t.Fatalf("Filter should drop empty books and collections: %+v", books)
+	}
+
+	var plain, md bytes.Buffer
+	Export(&plain, db, FormatPlain)
+	Export(&md, db, FormatMarkdown)
+	if !strings.HasPrefix(plain.String(), "John 3:16 For God so loved") {
+		t.Errorf("Plain export should be one reference per line:\n%s", plain.String())
+	}
+	for _, want := range []string{"## John", "**16** For God", "[^3-1]:"} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown export missing %q:\n%s", want, md.String())
+		}
+	}
+	if err := Export(&plain, db, "docx"); err == nil {
+		t.Error("An unknown format should be an error")
+	}
+}
+
+func TestExportFilteredKeepsVersification(t *testing.T) {
+	db := studyBible(t)
+	db.Versification = LXX.ID
+	var buf bytes.Buffer
+	sub := db.Filter(func(b *Book, c *Chapter, v *Verse) bool { return b.Name == "Genesis" })
+	if err := Export(&buf, sub, FormatJSON); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	back, err := ParseDatabase(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Exported JSON does not load: %v", err)
+	}
+	if back.Scheme() != LXX {
+		t.Errorf("A filtered export should keep its versification, got %q", back.Versification)
+	}
+}
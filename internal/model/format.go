@@ -0,0 +1,75 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Formats understood by Parse. USFM is also accepted, one book per file.
+const (
+	FormatJSON    = "json"
+	FormatUSFM    = "usfm"
+	FormatOSIS    = "osis"
+	FormatZefania = "zefania"
+	FormatCSV     = "csv"
+	FormatTSV     = "tsv"
+)
+
+// Formats lists every supported format name.
+var Formats = []string{FormatJSON, FormatUSFM, FormatOSIS, FormatZefania, FormatCSV, FormatTSV}
+
+// DetectFormat guesses a file's format from its extension. XML files are sniffed to tell
+// Zefania (<XMLBIBLE>) from OSIS. It returns "" for files that are not translations.
+func DetectFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".usfm", ".sfm":
+		return FormatUSFM
+	case ".osis":
+		return FormatOSIS
+	case ".csv":
+		return FormatCSV
+	case ".tsv", ".tab":
+		return FormatTSV
+	case ".xml":
+		if data != nil && bytes.Contains(bytes.ToUpper(head(data, 4096)), []byte("<XMLBIBLE")) {
+			return FormatZefania
+		}
+		return FormatOSIS
+	}
+	return ""
+}
+
+// Parse reads one translation file in the given format.
+func Parse(data []byte, format string) (*Bible, error) {
+	return parse(data, format, nil)
+}
+
+// parse is Parse, reporting to issues (if not nil) the CSV rows it can skip rather than failing.
+func parse(data []byte, format string, issues *Report) (*Bible, error) {
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		return ParseDatabase(data)
+	case FormatUSFM:
+		return ParseUSFM(data)
+	case FormatOSIS:
+		return ParseOSIS(data)
+	case FormatZefania:
+		return ParseZefania(data)
+	case FormatCSV:
+		return parseCSV(data, ',', issues)
+	case FormatTSV:
+		return parseCSV(data, '\t', issues)
+	}
+	return nil, fmt.Errorf("unknown format '%s' (supported: %s)", format, strings.Join(Formats, ", "))
+}
+
+func head(data []byte, n int) []byte {
+	if len(data) > n {
+		return data[:n]
+	}
+	return data
+}
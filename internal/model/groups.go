@@ -0,0 +1,88 @@
+package model
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+//go:embed groups.json
+var groupsData []byte
+
+// BookGroup is a traditional grouping of books, such as the Gospels or the Minor Prophets.
+type BookGroup struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Books   []string `json:"books"` // OSIS IDs; in groups.json, "Rom-Phlm" stands for a run of books
+}
+
+// BuiltinGroups holds the groups defined in groups.json.
+var BuiltinGroups = loadBuiltinGroups()
+
+// ParseBookGroups reads group definitions, turning runs of books ("Matt-John") into the OSIS IDs
+// of every book in them, in canonical order.
+func ParseBookGroups(data []byte) ([]BookGroup, error) {
+	var groups []BookGroup
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return nil, err
+	}
+	for i, g := range groups {
+		var books []string
+		for _, run := range g.Books {
+			from, to, _ := strings.Cut(run, "-")
+			if to == "" {
+				to = from
+			}
+			_, first, ok := LookupCanon(from)
+			if !ok {
+				return nil, fmt.Errorf("group %s: unknown book %q", g.Name, from)
+			}
+			_, last, ok := LookupCanon(to)
+			if !ok {
+				return nil, fmt.Errorf("group %s: unknown book %q", g.Name, to)
+			}
+			if last < first {
+				return nil, fmt.Errorf("group %s: %s ends before it starts", g.Name, run)
+			}
+			for _, info := range Canon[first : last+1] {
+				books = append(books, info.OSIS)
+			}
+		}
+		if len(books) == 0 {
+			return nil, fmt.Errorf("group %s has no books", g.Name)
+		}
+		groups[i].Books = books
+	}
+	return groups, nil
+}
+
+// LookupGroup finds a built-in group by name or alias ("gospels", "minor prophets").
+func LookupGroup(name string) (BookGroup, bool) {
+	key := NormalizeName(name)
+	for _, g := range BuiltinGroups {
+		if NormalizeName(g.Name) == key {
+			return g, true
+		}
+		for _, alias := range g.Aliases {
+			if NormalizeName(alias) == key {
+				return g, true
+			}
+		}
+	}
+	return BookGroup{}, false
+}
+
+// Holds reports whether a book, by OSIS ID, belongs to the group.
+func (g BookGroup) Holds(osis string) bool {
+	return slices.Contains(g.Books, osis)
+}
+
+func loadBuiltinGroups() []BookGroup {
+	groups, err := ParseBookGroups(groupsData)
+	if err != nil {
+		panic(fmt.Sprintf("groups.json: %v", err))
+	}
+	return groups
+}
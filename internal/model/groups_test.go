@@ -0,0 +1,62 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinGroups(t *testing.T) {
+	tests := []struct {
+		name  string
+		books []string
+	}{
+		{"Gospels", []string{"Matt", "Mark", "Luke", "John"}},
+		{"pentateuch", []string{"Gen", "Exod", "Lev", "Num", "Deut"}},
+		{"TORAH", []string{"Gen", "Exod", "Lev", "Num", "Deut"}},
+		{"major prophets", []string{"Isa", "Jer", "Lam", "Ezek", "Dan"}},
+		{"Prison Epistles", []string{"Eph", "Phil", "Col", "Phlm"}},
+	}
+	for _, tt := range tests {
+		g, ok := LookupGroup(tt.name)
+		if !ok || !reflect.DeepEqual(g.Books, tt.books) {
+			t.Errorf("LookupGroup(%q) = %v, want %v", tt.name, g.Books, tt.books)
+		}
+	}
+
+	if g, _ := LookupGroup("minor prophets"); len(g.Books) != 12 || !g.Holds("Jonah") || g.Holds("Dan") {
+		t.Errorf("Expected the twelve Minor Prophets, got %v", g.Books)
+	}
+	if g, _ := LookupGroup("pauline epistles"); len(g.Books) != 13 {
+		t.Errorf("Expected Romans to Philemon, got %v", g.Books)
+	}
+	if _, ok := LookupGroup("john"); ok {
+		t.Error("A book is not a group")
+	}
+
+	// A group must not hide a book of the same name
+	for _, g := range BuiltinGroups {
+		for _, name := range append([]string{g.Name}, g.Aliases...) {
+			if info, _, ok := LookupCanon(name); ok {
+				t.Errorf("Group name %q is also the book %s", name, info.Name)
+			}
+		}
+	}
+}
+
+func TestParseBookGroups(t *testing.T) {
+	tests := []struct {
+		data string
+		err  string
+	}{
+		{`[{"name": "Odd", "books": ["Gen-Foo"]}]`, `unknown book "Foo"`},
+		{`[{"name": "Odd", "books": ["Mal-Gen"]}]`, "ends before it starts"},
+		{`[{"name": "Odd", "books": []}]`, "has no books"},
+		{`{"name": "Odd"}`, "cannot unmarshal"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseBookGroups([]byte(tt.data)); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("ParseBookGroups(%s): expected an error with %q, got %v", tt.data, tt.err, err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -19,24 +20,71 @@ func TestBibleStructure(t *testing.T) {
 		t.Fatalf("Failed to parse valid JSON: %v", err)
 	}
 
-	// Verify Hierarchy: Testament -> Book -> Chapter -> Verse
-	book, ok := db.OT["Genesis"]
-	if !ok {
+	// Verify Hierarchy: Collection -> Book -> Chapter -> Verse
+	book := db.Collection("OT").Book("Genesis")
+	if book == nil {
 		t.Fatal("Failed to map 'Genesis' inside OT")
 	}
 
-	chapter, ok := book["1"]
-	if !ok {
+	chapter := book.Chapter(1)
+	if chapter == nil {
 		t.Fatal("Failed to map Chapter 1 inside Genesis")
 	}
 
-	text, ok := chapter["1"]
-	if !ok {
+	verse := chapter.Verse(1)
+	if verse == nil {
 		t.Fatal("Failed to map Verse 1 inside Chapter 1")
 	}
 
-	if text != "In the beginning" {
-		t.Errorf("Verse content mismatch. Got '%s'", text)
+	if verse.Text != "In the beginning" {
+		t.Errorf("Verse content mismatch. Got '%s'", verse.Text)
+	}
+	if book.OSIS != "Gen" {
+		t.Errorf("Expected OSIS ID 'Gen', got '%s'", book.OSIS)
+	}
+}
+
+func TestCanonicalOrder(t *testing.T) {
+	jsonData := []byte(`{
+		"OT": {
+			"Amos": { "1": { "1": "The words of Amos" } },
+			"1 Kings": { "1": { "1": "Now king David was old" } },
+			"Genesis": {
+				"10": { "1": "Now these are the generations" },
+				"2": { "10": "And a river went out", "9": "And out of the ground" },
+				"1": { "1": "In the beginning" }
+			}
+		},
+		"NT": {}
+	}`)
+
+	db, err := ParseDatabase(jsonData)
+	if err != nil {
+		t.Fatalf("Failed to parse valid JSON: %v", err)
+	}
+
+	var names []string
+	for _, b := range db.Collection("OT").Books {
+		names = append(names, b.Name)
+	}
+	if strings.Join(names, ",") != "Genesis,1 Kings,Amos" {
+		t.Errorf("Books not in canonical order: %v", names)
+	}
+
+	gen := db.Book("genesis")
+	if gen.Chapters[0].Number != 1 || gen.Chapters[2].Number != 10 {
+		t.Error("Chapters should be sorted numerically")
+	}
+	if gen.Chapter(2).Verses[0].Number != 9 {
+		t.Error("Verses should be sorted numerically")
+	}
+}
+
+func TestNonNumericKeys(t *testing.T) {
+	badData := []byte(`{ "OT": { "Genesis": { "one": { "1": "In the beginning" } } } }`)
+
+	if _, err := ParseDatabase(badData); err == nil {
+		t.Error("Parser should reject non-numeric chapter keys")
 	}
 }
 
@@ -58,3 +106,56 @@ func TestEmptyData(t *testing.T) {
 		t.Error("Should have failed on empty data")
 	}
 }
+
+func TestCollections(t *testing.T) {
+	jsonData := []byte(`{
+		"meta": { "id": "nab", "collections": { "DC": "Deuterocanon" } },
+		"NT": { "John": { "3": { "16": "For God so loved the world" } } },
+		"DC": {
+			"Sirach": { "1": { "1": "All wisdom is from the Lord" } },
+			"Tobit":  { "1": { "1": "This book tells the story of Tobit" } }
+		},
+		"OT": { "Genesis": { "1": { "1": "In the beginning" } } },
+		"XX": { "Odes": { "1": { "1": "Let us sing to the Lord" } } }
+	}`)
+
+	db, err := ParseDatabase(jsonData)
+	if err != nil {
+		t.Fatalf("Failed to parse collections: %v", err)
+	}
+
+	var ids []string
+	for _, c := range db.Collections {
+		ids = append(ids, c.ID)
+	}
+	if strings.Join(ids, ",") != "OT,DC,NT,XX" {
+		t.Errorf("Collections should follow reading order with unknown ones last, got %v", ids)
+	}
+	dc := db.Collection("dc")
+	if dc == nil || dc.Name != "Deuterocanon" || dc.Books[0].Name != "Tobit" || dc.Books[0].Collection != "DC" {
+		t.Fatalf("DC books should be ordered canonically under the data's name: %+v", dc)
+	}
+	if db.Collection("AP") != dc || db.Collection("deuterocanon") != dc {
+		t.Error("The DC collection should also be found as AP and by its name")
+	}
+
+	data, err := db.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := ParseDatabase(data)
+	if err != nil || back.Collection("DC").Name != "Deuterocanon" || back.Book("Odes") == nil {
+		t.Errorf("Collections should survive a round trip: %s", data)
+	}
+}
+
+func TestCanonDeuterocanon(t *testing.T) {
+	for _, name := range []string{"Tobit", "Ecclesiasticus", "1 Maccabees", "I Maccabees", "Wisdom of Solomon"} {
+		if info, _, ok := LookupCanon(name); !ok || info.Collection != "DC" {
+			t.Errorf("%s should be a DC book, got %+v", name, info)
+		}
+	}
+	if !SameCollection("ap", "DC") || SameCollection("OT", "DC") {
+		t.Error("AP and DC should be the same collection, OT and DC not")
+	}
+}
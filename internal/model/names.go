@@ -0,0 +1,161 @@
+package model
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//go:embed names/*.json
+var namesFS embed.FS
+
+// NameTable lists what readers of one language call each book: its name first, then
+// abbreviations and other spellings, e.g. "John": ["Jean", "Jn"].
+type NameTable struct {
+	Language string              `json:"language"`
+	Name     string              `json:"name,omitempty"`
+	Books    map[string][]string `json:"books"` // OSIS ID -> names
+}
+
+// BuiltinNames holds the name tables shipped in names/, in language order.
+var BuiltinNames = loadBuiltinNames()
+
+// localIndex maps the built-in book names (not abbreviations) of every language to a position in Canon,
+// so translations may name their books in their own language.
+var localIndex = buildLocalIndex()
+
+// ParseNameTable reads a name table. Books may be keyed by OSIS ID or by English name.
+func ParseNameTable(data []byte) (*NameTable, error) {
+	var t NameTable
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	if t.Language == "" {
+		return nil, fmt.Errorf("name table has no language")
+	}
+	books := make(map[string][]string, len(t.Books))
+	for id, names := range t.Books {
+		i, ok := canonIndex[NormalizeName(id)]
+		if !ok {
+			return nil, fmt.Errorf("name table %s: unknown book %q", t.Language, id)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("name table %s: no names for %s", t.Language, id)
+		}
+		books[Canon[i].OSIS] = names
+	}
+	t.Books = books
+	return &t, nil
+}
+
+// LoadNameTables reads a name table file, or every *.json table in a directory.
+func LoadNameTables(path string) ([]*NameTable, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return nil, err
+		}
+	}
+
+	var tables []*NameTable
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		t, err := ParseNameTable(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filepath.Base(file), err)
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// Names returns what the table calls a book, its name first; nil if the table leaves it out.
+func (t *NameTable) Names(osis string) []string {
+	return t.Books[osis]
+}
+
+// Lookup finds the book a name or abbreviation of this table stands for.
+func (t *NameTable) Lookup(name string) (BookInfo, bool) {
+	key := NormalizeName(name)
+	for _, info := range Canon {
+		for _, n := range t.Books[info.OSIS] {
+			if NormalizeName(n) == key {
+				return info, true
+			}
+		}
+	}
+	return BookInfo{}, false
+}
+
+// LookupBookName finds a book by canonical name, alias or OSIS ID, then by a name or
+// abbreviation from the given tables, in order.
+func LookupBookName(name string, tables []*NameTable) (BookInfo, bool) {
+	if info, _, ok := LookupCanon(name); ok {
+		return info, true
+	}
+	for _, t := range tables {
+		if info, ok := t.Lookup(name); ok {
+			return info, true
+		}
+	}
+	return BookInfo{}, false
+}
+
+// SameLanguage compares language tags by their primary language, so "fr" matches "fr-CA".
+// An empty tag is English, the language of the embedded translation.
+func SameLanguage(a, b string) bool {
+	primary := func(tag string) string {
+		tag, _, _ = strings.Cut(strings.ToLower(strings.ReplaceAll(tag, "_", "-")), "-")
+		if tag == "" {
+			return "en"
+		}
+		return tag
+	}
+	return primary(a) == primary(b)
+}
+
+func loadBuiltinNames() []*NameTable {
+	files, err := namesFS.ReadDir("names")
+	if err != nil {
+		panic(err)
+	}
+	var tables []*NameTable
+	for _, f := range files {
+		data, err := namesFS.ReadFile("names/" + f.Name())
+		if err != nil {
+			panic(err)
+		}
+		t, err := ParseNameTable(data)
+		if err != nil {
+			panic(fmt.Sprintf("names/%s: %v", f.Name(), err))
+		}
+		tables = append(tables, t)
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Language < tables[j].Language })
+	return tables
+}
+
+func buildLocalIndex() map[string]int {
+	idx := make(map[string]int)
+	for _, t := range BuiltinNames {
+		for i, info := range Canon {
+			if names := t.Names(info.OSIS); len(names) > 0 {
+				if _, taken := idx[NormalizeName(names[0])]; !taken {
+					idx[NormalizeName(names[0])] = i
+				}
+			}
+		}
+	}
+	return idx
+}
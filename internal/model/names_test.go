@@ -0,0 +1,94 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuiltinNames(t *testing.T) {
+	if len(BuiltinNames) < 4 {
+		t.Fatalf("Expected the en, fr, es and de tables, got %d", len(BuiltinNames))
+	}
+	// A book's name in any language must lead back to that book, so translations can use it as a key
+	for _, table := range BuiltinNames {
+		for osis, names := range table.Books {
+			if info, _, ok := LookupCanon(names[0]); !ok || info.OSIS != osis {
+				t.Errorf("%s: %q should find %s, got %+v", table.Language, names[0], osis, info)
+			}
+		}
+	}
+
+	tests := map[string]string{"Genèse": "Gen", "genese": "Gen", "Johannes": "John", "Apocalipsis": "Rev"}
+	for name, want := range tests {
+		if info, _, ok := LookupCanon(name); !ok || info.OSIS != want {
+			t.Errorf("LookupCanon(%q) = %+v; want %s", name, info, want)
+		}
+	}
+	if info, ok := LookupBookName("1 Kor", BuiltinNames); !ok || info.OSIS != "1Cor" {
+		t.Errorf("Abbreviations should be found through the tables, got %+v", info)
+	}
+	if _, _, ok := LookupCanon("1 Kor"); ok {
+		t.Error("Abbreviations should not be taken as book names in data files")
+	}
+}
+
+func TestParseNameTable(t *testing.T) {
+	table, err := ParseNameTable([]byte(`{"language": "sw", "books": {"John": ["Yohana", "Yh"], "Gen": ["Mwanzo"]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, ok := table.Lookup("yh"); !ok || info.OSIS != "John" {
+		t.Errorf("Lookup should find John by its abbreviation, got %+v", info)
+	}
+
+	for _, bad := range []string{
+		`{"books": {"John": ["Yohana"]}}`,
+		`{"language": "sw", "books": {"Yohana": ["Yohana"]}}`,
+		`{"language": "sw", "books": {"John": []}}`,
+	} {
+		if _, err := ParseNameTable([]byte(bad)); err == nil {
+			t.Errorf("ParseNameTable(%s) should fail", bad)
+		}
+	}
+}
+
+func TestRegistryNameTables(t *testing.T) {
+	dir := t.TempDir()
+	custom := `{"language": "fr", "books": {"John": ["Jehan"]}}`
+	if err := os.WriteFile(filepath.Join(dir, "fr.json"), []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry(&Bible{ID: "kjv"}, &Bible{ID: "rv", Language: "es-MX"})
+	if err := r.LoadNames(dir); err != nil {
+		t.Fatal(err)
+	}
+	var langs []string
+	for _, table := range r.NameTables("fr") {
+		langs = append(langs, table.Language)
+	}
+	// The custom French table first, then the built-in French, English and the loaded Spanish
+	want := []string{"fr", "fr", "en", "es"}
+	if len(langs) != len(want) || r.NameTables("fr")[0].Names("John")[0] != "Jehan" {
+		t.Fatalf("NameTables(fr) = %v; want %v with the custom table first", langs, want)
+	}
+	for i := range want {
+		if langs[i] != want[i] {
+			t.Errorf("NameTables(fr) = %v; want %v", langs, want)
+			break
+		}
+	}
+}
+
+func TestLocalizedTranslation(t *testing.T) {
+	db, err := ParseDatabase([]byte(`{"meta": {"id": "lsg", "language": "fr"},
+		"NT": {"Jean": {"3": {"16": "Car Dieu a tant aimé le monde"}}, "Matthieu": {"1": {"1": "Généalogie de Jésus-Christ"}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	books := db.Books()
+	if books[0].Name != "Matthieu" || books[1].OSIS != "John" {
+		t.Errorf("French book names should be recognized and ordered canonically, got %s, %s", books[0].Name, books[1].Name)
+	}
+}
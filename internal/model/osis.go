@@ -0,0 +1,253 @@
+package model
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// osisFrame remembers what an open OSIS element started, so its end tag can undo it.
+type osisFrame struct {
+	name      string
+	milestone bool // sID/eID element: its end tag closes nothing
+	book      bool
+	wj        bool
+	note      bool
+	title     bool
+	skip      bool
+}
+
+type osisParser struct {
+	verseBuilder
+	bible *Bible
+	books []*Book
+
+	stack   []osisFrame
+	inVerse bool // Inside a container <verse> or between verse sID/eID milestones
+
+	note  *Note
+	title *strings.Builder
+
+	jesusQuotes map[string]bool // sIDs of open <q who="Jesus"> milestones, which their eID closes
+	bookSID     string          // sID of the open book milestone, which its eID closes
+}
+
+// ParseOSIS reads an OSIS XML document into a Bible. Both container elements and
+// milestone (sID/eID) chapters, verses and quotes are understood. Section titles,
+// paragraphs, notes and words of Jesus (<q who="Jesus">) are kept; cross references are dropped.
+func ParseOSIS(data []byte) (*Bible, error) {
+	p := &osisParser{verseBuilder: newVerseBuilder(), bible: &Bible{}, jesusQuotes: make(map[string]bool)}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("OSIS: %v", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if err := p.start(t); err != nil {
+				return nil, err
+			}
+		case xml.EndElement:
+			p.end()
+		case xml.CharData:
+			p.text(string(t))
+		}
+	}
+	p.finishVerse()
+
+	if len(p.books) == 0 {
+		return nil, fmt.Errorf("OSIS: no books found")
+	}
+	db := assemble(p.books)
+	db.ID, db.Name, db.Language = p.bible.ID, p.bible.Name, p.bible.Language
+	return db, nil
+}
+
+func (p *osisParser) start(el xml.StartElement) error {
+	frame := osisFrame{name: el.Name.Local}
+	sID, eID := osisAttr(el, "sID"), osisAttr(el, "eID")
+	frame.milestone = sID != "" || eID != ""
+	if parent := p.top(); parent.skip {
+		frame.skip = true
+	}
+
+	switch el.Name.Local {
+	case "osisText":
+		p.bible.ID = strings.ToLower(osisAttr(el, "osisIDWork"))
+		p.bible.Language = osisAttr(el, "lang")
+	case "header":
+		frame.skip = true
+	case "title":
+		if p.inHeader() && p.bible.Name == "" {
+			frame.title = true
+			p.title = &strings.Builder{}
+		} else if p.chapter != nil && !frame.skip {
+			frame.title = true
+			p.title = &strings.Builder{}
+		}
+	case "div":
+		switch {
+		case eID != "" && eID == p.bookSID:
+			p.finishVerse()
+			p.book, p.chapter, p.bookSID = nil, nil, ""
+		case osisAttr(el, "type") == "book":
+			p.startBook(osisAttr(el, "osisID"))
+			p.bookSID = sID
+			frame.book = !frame.milestone // A milestone's end tag comes at once and closes nothing
+		}
+	case "chapter":
+		if eID != "" || p.book == nil {
+			break
+		}
+		id := osisAttr(el, "osisID")
+		if id == "" {
+			id = sID
+		}
+		num, err := strconv.Atoi(lastSegment(id))
+		if err != nil {
+			return fmt.Errorf("OSIS: invalid chapter osisID %q", id)
+		}
+		p.startChapter(num)
+	case "verse":
+		if eID != "" {
+			p.finishVerse()
+			p.inVerse = false
+			break
+		}
+		if p.chapter == nil {
+			break
+		}
+		ids := strings.Fields(osisAttr(el, "osisID")) // "Gen.1.1 Gen.1.2" for bridged verses
+		if len(ids) == 0 {
+			ids = []string{sID}
+		}
+		num, err := strconv.Atoi(lastSegment(ids[0]))
+		if err != nil {
+			return fmt.Errorf("OSIS: invalid verse osisID %q", ids[0])
+		}
+		through, _ := strconv.Atoi(lastSegment(ids[len(ids)-1]))
+		p.startVerse(num, through)
+		p.inVerse = true
+	case "p":
+		p.markParagraph()
+	case "milestone":
+		if t := osisAttr(el, "type"); t == "x-p" || t == "pilcrow" {
+			p.markParagraph()
+		}
+	case "l", "lb":
+		p.space()
+	case "q":
+		// The end milestone of a quote often leaves out who is speaking: <q eID="q1"/>
+		switch who := osisAttr(el, "who"); {
+		case eID != "":
+			if p.jesusQuotes[eID] || who == "Jesus" {
+				delete(p.jesusQuotes, eID)
+				p.closeWordsOfJesus()
+			}
+		case who == "Jesus":
+			p.openWordsOfJesus()
+			if sID != "" {
+				p.jesusQuotes[sID] = true
+			}
+			frame.wj = !frame.milestone
+		}
+	case "note":
+		if osisAttr(el, "type") == "crossReference" {
+			frame.skip = true
+		} else if !frame.skip {
+			frame.note = true
+			p.note = &Note{Caller: osisAttr(el, "n"), Offset: p.noteOffset()}
+		}
+	}
+	p.stack = append(p.stack, frame)
+	return nil
+}
+
+func (p *osisParser) end() {
+	if len(p.stack) == 0 {
+		return
+	}
+	frame := p.top()
+	p.stack = p.stack[:len(p.stack)-1]
+
+	switch {
+	case frame.title && p.title != nil:
+		if p.chapter == nil {
+			p.bible.Name = collapseSpaces(strings.TrimSpace(p.title.String()))
+		} else {
+			p.addHeading(p.title.String())
+		}
+		p.title = nil
+	case frame.note && p.note != nil:
+		p.addNote(*p.note)
+		p.note = nil
+	case frame.wj:
+		p.closeWordsOfJesus()
+	case frame.name == "verse" && !frame.milestone:
+		p.finishVerse()
+		p.inVerse = false
+	case frame.book:
+		p.finishVerse()
+		p.book, p.chapter = nil, nil
+	}
+}
+
+func (p *osisParser) text(s string) {
+	switch {
+	case p.title != nil:
+		p.title.WriteString(s)
+	case p.top().skip:
+	case p.note != nil:
+		p.note.Text += s
+	case p.inVerse:
+		p.appendText(s)
+	}
+}
+
+func (p *osisParser) startBook(osisID string) {
+	p.finishVerse()
+	p.chapter = nil
+	if _, _, ok := LookupCanon(osisID); !ok {
+		p.book = nil // Books outside the canon table are skipped
+		return
+	}
+	p.book = newBook("", osisID)
+	p.books = append(p.books, p.book)
+}
+
+func (p *osisParser) top() osisFrame {
+	if len(p.stack) == 0 {
+		return osisFrame{}
+	}
+	return p.stack[len(p.stack)-1]
+}
+
+func (p *osisParser) inHeader() bool {
+	for _, f := range p.stack {
+		if f.name == "header" {
+			return true
+		}
+	}
+	return false
+}
+
+func osisAttr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// lastSegment returns "16" for "John.3.16".
+func lastSegment(osisID string) string {
+	return osisID[strings.LastIndex(osisID, ".")+1:]
+}
@@ -0,0 +1,130 @@
+package model
+
+import "testing"
+
+const sampleOSIS = `<?xml version="1.0" encoding="UTF-8"?>
+<osis xmlns="http://www.bibletechnologies.net/2003/OSIS/namespace">
+<osisText osisIDWork="KJV" xml:lang="en">
+  <header>
+    <work osisWork="KJV"><title>King James Version (1769)</title></work>
+  </header>
+  <div type="book" osisID="John">
+    <title type="main">THE GOSPEL ACCORDING TO ST. JOHN</title>
+    <chapter osisID="John.3">
+      <title type="section">Jesus and Nicodemus</title>
+      <p>
+      <verse osisID="John.3.16">For God so loved the world, that he gave his only begotten Son,<note type="study" n="a">Or, <hi type="italic">unique</hi></note> that whosoever believeth in him should not perish, but have everlasting life.</verse>
+      <verse osisID="John.3.17">For God sent not his Son into the world<note type="crossReference"><reference osisRef="John.5.24">John 5:24</reference></note> to condemn the world.</verse>
+      </p>
+    </chapter>
+  </div>
+  <div type="book" osisID="Gen">
+    <chapter sID="Gen.1" osisID="Gen.1"/>
+    <milestone type="x-p" marker="¶"/><verse sID="Gen.1.1" osisID="Gen.1.1"/>In the beginning God created the heaven and the earth.<verse eID="Gen.1.1"/>
+    <verse sID="Gen.1.2" osisID="Gen.1.2"/>And the earth was <transChange type="added">without form</transChange>.<verse eID="Gen.1.2"/>
+    <chapter eID="Gen.1"/>
+  </div>
+  <div type="book" osisID="Matt">
+    <chapter osisID="Matt.5">
+      <verse sID="Matt.5.3" osisID="Matt.5.3"/><q who="Jesus" sID="q1"/>Blessed are the poor in spirit:<verse eID="Matt.5.3"/>
+      <verse sID="Matt.5.4" osisID="Matt.5.4"/>Blessed are they that mourn.<q who="Jesus" eID="q1"/> And he taught.<verse eID="Matt.5.4"/>
+      <verse osisID="Matt.5.5"><q who="Jesus">Blessed are the meek</q>: for they shall inherit the earth.</verse>
+    </chapter>
+  </div>
+</osisText>
+</osis>`
+
+func TestParseOSIS(t *testing.T) {
+	db, err := ParseOSIS([]byte(sampleOSIS))
+	if err != nil {
+		t.Fatalf("ParseOSIS failed: %v", err)
+	}
+	if db.ID != "kjv" || db.Name != "King James Version (1769)" || db.Language != "en" {
+		t.Errorf("Work metadata not read: %q %q %q", db.ID, db.Name, db.Language)
+	}
+	if db.Books()[0].Name != "Genesis" {
+		t.Error("Books should be in canonical order")
+	}
+
+	v16 := db.Book("John").Chapter(3).Verse(16)
+	if v16.Text != "For God so loved the world, that he gave his only begotten Son, that whosoever believeth in him should not perish, but have everlasting life." {
+		t.Errorf("Container verse text mismatch: %q", v16.Text)
+	}
+	if v16.Heading != "Jesus and Nicodemus" || !v16.Paragraph {
+		t.Errorf("Section title and paragraph should be kept: %q / %v", v16.Heading, v16.Paragraph)
+	}
+	if len(v16.Notes) != 1 || v16.Notes[0].Text != "Or, unique" || v16.Notes[0].Caller != "a" {
+		t.Errorf("Study note not captured: %+v", v16.Notes)
+	}
+	if v17 := db.Book("John").Chapter(3).Verse(17); v17.Text != "For God sent not his Son into the world to condemn the world." || len(v17.Notes) != 0 {
+		t.Errorf("Cross references should be dropped: %+v", v17)
+	}
+
+	gen := db.Book("Genesis").Chapter(1)
+	if len(gen.Verses) != 2 || gen.Verse(1).Text != "In the beginning God created the heaven and the earth." {
+		t.Errorf("Milestone verses not read: %+v", gen.Verses)
+	}
+	if !gen.Verse(1).Paragraph || gen.Verse(2).Text != "And the earth was without form." {
+		t.Errorf("Pilcrow milestone or added words lost: %+v", gen.Verse(2))
+	}
+
+	matt := db.Book("Matthew").Chapter(5)
+	if s := matt.Verse(3).Spans; len(s) != 1 || s[0].Start != 0 || s[0].End != len(matt.Verse(3).Text) {
+		t.Errorf("Milestone quote should span all of 5:3: %+v", s)
+	}
+	if s := matt.Verse(4).Spans; len(s) != 1 || matt.Verse(4).Text[s[0].Start:s[0].End] != "Blessed are they that mourn." {
+		t.Errorf("Milestone quote should close inside 5:4: %+v", s)
+	}
+	if s := matt.Verse(5).Spans; len(s) != 1 || matt.Verse(5).Text[s[0].Start:s[0].End] != "Blessed are the meek" {
+		t.Errorf("Container quote span wrong: %+v", s)
+	}
+}
+
+func TestParseOSISUnattributedQuoteEnd(t *testing.T) {
+	db, err := ParseOSIS([]byte(`<osis><osisText><div type="book" osisID="John">
+		<chapter sID="John.3" osisID="John.3"/>
+		<verse sID="John.3.3" osisID="John.3.3"/>Jesus answered, <q sID="q1" who="Jesus"/>Verily, verily.<q eID="q1"/> Nicodemus said,<verse eID="John.3.3"/>
+		<verse sID="John.3.4" osisID="John.3.4"/><q sID="q2"/>How can a man be born?<q eID="q2"/><verse eID="John.3.4"/>
+		<chapter eID="John.3"/></div></osisText></osis>`))
+	if err != nil {
+		t.Fatalf("ParseOSIS failed: %v", err)
+	}
+	v3 := db.Book("John").Chapter(3).Verse(3)
+	if s := v3.Spans; len(s) != 1 || v3.Text[s[0].Start:s[0].End] != "Verily, verily." {
+		t.Errorf("An eID without who should close the words of Jesus: %+v in %q", s, v3.Text)
+	}
+	if s := db.Book("John").Chapter(3).Verse(4).Spans; len(s) != 0 {
+		t.Errorf("Narration after the quote should not be words of Jesus: %+v", s)
+	}
+}
+
+func TestParseOSISBookMilestones(t *testing.T) {
+	db, err := ParseOSIS([]byte(`<osis><osisText>
+		<div type="book" sID="b1" osisID="Gen"/>
+		<chapter sID="Gen.1" osisID="Gen.1"/><verse sID="Gen.1.1" osisID="Gen.1.1"/>In the beginning.<verse eID="Gen.1.1"/><chapter eID="Gen.1"/>
+		<chapter sID="Gen.2" osisID="Gen.2"/><verse sID="Gen.2.1" osisID="Gen.2.1"/>Thus the heavens.<verse eID="Gen.2.1"/><chapter eID="Gen.2"/>
+		<div type="book" eID="b1"/>
+		<chapter osisID="Exod.1"><verse osisID="Exod.1.1">Outside any book.</verse></chapter>
+		<div type="book" sID="b2" osisID="Exod"/>
+		<chapter sID="Exod.1" osisID="Exod.1"/><verse sID="Exod.1.1" osisID="Exod.1.1"/>Now these are the names.<verse eID="Exod.1.1"/><chapter eID="Exod.1"/>
+		<div type="book" eID="b2"/></osisText></osis>`))
+	if err != nil {
+		t.Fatalf("ParseOSIS failed: %v", err)
+	}
+	gen := db.Book("Genesis")
+	if gen == nil || len(gen.Chapters) != 2 || gen.Chapter(2).Verse(1).Text != "Thus the heavens." {
+		t.Fatalf("Chapters inside book milestones should be kept: %+v", gen)
+	}
+	if v := db.Book("Exodus").Chapter(1).Verses; len(v) != 1 || v[0].Text != "Now these are the names." {
+		t.Errorf("A book's eID should close it: %+v", v)
+	}
+}
+
+func TestParseOSISErrors(t *testing.T) {
+	if _, err := ParseOSIS([]byte(`<osis><osisText><div type="book" osisID="Gen">`)); err == nil {
+		t.Error("Truncated XML should be an error")
+	}
+	if _, err := ParseOSIS([]byte(`<osis><osisText></osisText></osis>`)); err == nil {
+		t.Error("A document without books should be an error")
+	}
+}
@@ -0,0 +1,231 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Registry holds every loaded translation in load order, keyed by its short ID.
+type Registry struct {
+	Bibles []*Bible
+
+	// Format forces the format of files loaded by LoadFile; empty means detect it from the file.
+	Format string
+
+	// Names holds book-name tables loaded on top of BuiltinNames (see LoadNames).
+	Names []*NameTable
+
+	// Skipped lists the translations NewRegistry and LoadDir could not add, each with the reason,
+	// so one bad file does not keep the others out.
+	Skipped []error
+}
+
+// NewRegistry registers the given translations in order. One that Add refuses (no ID, or an ID
+// already taken) is left out and recorded in Skipped.
+func NewRegistry(bibles ...*Bible) *Registry {
+	r := &Registry{}
+	for _, b := range bibles {
+		if err := r.Add(b); err != nil {
+			r.Skipped = append(r.Skipped, err)
+		}
+	}
+	return r
+}
+
+// Add registers a translation. IDs are case-insensitive and must be unique.
+func (r *Registry) Add(b *Bible) error {
+	if b.ID == "" {
+		return fmt.Errorf("translation has no ID")
+	}
+	if r.Get(b.ID) != nil {
+		return fmt.Errorf("translation '%s' is already loaded", b.ID)
+	}
+	r.Bibles = append(r.Bibles, b)
+	return nil
+}
+
+// Get returns the translation with the given ID, or nil.
+func (r *Registry) Get(id string) *Bible {
+	for _, b := range r.Bibles {
+		if strings.EqualFold(b.ID, id) {
+			return b
+		}
+	}
+	return nil
+}
+
+// IDs lists the registered translation IDs in load order.
+func (r *Registry) IDs() []string {
+	ids := make([]string, 0, len(r.Bibles))
+	for _, b := range r.Bibles {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+// LoadRegistry loads the translations at dataPath (a translation file or a directory of them), then the
+// embedded KJV, then any translations in ~/.bible_translations and book-name tables in its names/
+// subdirectory. The first translation loaded is the
+// default; with an empty dataPath that is the embedded copy. A non-empty format overrides format
+// detection for the files under dataPath. Once the embedded copy is loaded, problems with the
+// files in ~/.bible_translations are recorded in Skipped rather than returned.
+func LoadRegistry(dataPath, format string) (*Registry, error) {
+	r := NewRegistry()
+	if dataPath != "" {
+		r.Format = format
+		err := r.LoadPath(dataPath)
+		r.Format = ""
+		if err != nil {
+			return nil, err
+		}
+		if len(r.Bibles) == 0 {
+			return nil, fmt.Errorf("no translations found in %s", dataPath)
+		}
+	}
+
+	db, err := LoadDatabase()
+	if err != nil {
+		return nil, err
+	}
+	if r.Get(db.ID) == nil {
+		r.Add(db)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return r, nil
+	}
+	if err := r.LoadDir(filepath.Join(home, ".bible_translations")); err != nil && !os.IsNotExist(err) {
+		r.Skipped = append(r.Skipped, err)
+	}
+	if err := r.LoadNames(filepath.Join(home, ".bible_translations", "names")); err != nil && !os.IsNotExist(err) {
+		r.Skipped = append(r.Skipped, fmt.Errorf("names: %v", err))
+	}
+	return r, nil
+}
+
+// LoadNames adds the book-name tables in a file or directory. They take precedence over the
+// built-in tables of the same language.
+func (r *Registry) LoadNames(path string) error {
+	tables, err := LoadNameTables(path)
+	if err != nil {
+		return err
+	}
+	r.Names = append(r.Names, tables...)
+	return nil
+}
+
+// NameTables lists the book-name tables that apply, most preferred first: those of language,
+// then English, then those of the other loaded translations' languages. Tables loaded with
+// LoadNames always apply, ahead of the built-in table of their language.
+func (r *Registry) NameTables(language string) []*NameTable {
+	var tables []*NameTable
+	seen := make(map[*NameTable]bool)
+	add := func(lang string) {
+		for _, t := range append(r.Names, BuiltinNames...) {
+			if !seen[t] && SameLanguage(t.Language, lang) {
+				seen[t] = true
+				tables = append(tables, t)
+			}
+		}
+	}
+	add(language)
+	add("en")
+	for _, b := range r.Bibles {
+		add(b.Language)
+	}
+	for _, t := range r.Names {
+		add(t.Language)
+	}
+	return tables
+}
+
+// LoadPath registers a single translation file, or every translation in a directory.
+func (r *Registry) LoadPath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return r.LoadDir(path)
+	}
+	return r.LoadFile(path)
+}
+
+// LoadDir registers every JSON, OSIS, Zefania or CSV/TSV translation in dir. Files without an ID of their own are
+// named after the file. USFM books (*.usfm, *.sfm) in dir, or directly in one of its subdirectories, form one
+// translation per directory, named after that directory; deeper directories are not searched. A file that does not parse, or repeats a loaded ID, is added to
+// Skipped and the others still load; only an unreadable dir is an error.
+func (r *Registry) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	skip := func(err error) {
+		if err != nil {
+			r.Skipped = append(r.Skipped, err)
+		}
+	}
+	if len(usfmFiles(dir)) > 0 {
+		skip(r.addUSFM(dir))
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case entry.IsDir() && len(usfmFiles(path)) > 0:
+			skip(r.addUSFM(path))
+		case !entry.IsDir() && isTranslationFile(entry.Name()):
+			skip(r.LoadFile(path))
+		}
+	}
+	return nil
+}
+
+// LoadFile parses and registers a single translation file in any supported format. The format
+// comes from r.Format, else from the file itself (see DetectFormat); unknown files are read as JSON.
+func (r *Registry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	format := r.Format
+	if format == "" {
+		format = DetectFormat(path, data)
+	}
+	if format == "" {
+		format = FormatJSON
+	}
+	db, err := Parse(data, format)
+	if err != nil {
+		return fmt.Errorf("%s: %v", filepath.Base(path), err)
+	}
+	if db.ID == "" {
+		db.ID = strings.ToLower(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	}
+	if db.Name == "" {
+		db.Name = strings.ToUpper(db.ID)
+	}
+	if err := r.Add(db); err != nil {
+		return fmt.Errorf("%s: %v", filepath.Base(path), err)
+	}
+	return nil
+}
+
+func (r *Registry) addUSFM(dir string) error {
+	db, err := LoadUSFMDir(dir)
+	if err != nil {
+		return err
+	}
+	if err := r.Add(db); err != nil {
+		return fmt.Errorf("%s: %v", filepath.Base(dir), err)
+	}
+	return nil
+}
+
+// isTranslationFile reports whether a file holds a whole translation on its own.
+func isTranslationFile(name string) bool {
+	format := DetectFormat(name, nil)
+	return format != "" && format != FormatUSFM
+}
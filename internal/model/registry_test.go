@@ -0,0 +1,115 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegistryAdd(t *testing.T) {
+	r := NewRegistry(&Bible{ID: "kjv"})
+
+	if err := r.Add(&Bible{ID: "KJV"}); err == nil {
+		t.Error("Duplicate IDs should be rejected regardless of case")
+	}
+	if err := r.Add(&Bible{}); err == nil {
+		t.Error("Translations without an ID should be rejected")
+	}
+	if err := r.Add(&Bible{ID: "web"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Get("Web") == nil {
+		t.Error("Get should be case-insensitive")
+	}
+	if ids := r.IDs(); len(ids) != 2 || ids[0] != "kjv" || ids[1] != "web" {
+		t.Errorf("IDs should keep load order, got %v", ids)
+	}
+
+	r = NewRegistry(&Bible{ID: "kjv"}, &Bible{ID: "KJV"}, &Bible{})
+	if len(r.Bibles) != 1 || len(r.Skipped) != 2 || !strings.Contains(r.Skipped[0].Error(), "already loaded") {
+		t.Errorf("NewRegistry should record the translations it refuses, got %v and %v", r.IDs(), r.Skipped)
+	}
+}
+
+func TestRegistryLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	withMeta := `{"meta": {"id": "lsg", "name": "Louis Segond"}, "OT": {"Genesis": {"1": {"1": "Au commencement"}}}}`
+	withoutMeta := `{"NT": {"John": {"1": {"1": "In the beginning was the Word"}}}}`
+	os.WriteFile(filepath.Join(dir, "segond.json"), []byte(withMeta), 0644)
+	os.WriteFile(filepath.Join(dir, "WEB.json"), []byte(withoutMeta), 0644)
+
+	r := NewRegistry()
+	if err := r.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	if b := r.Get("lsg"); b == nil || b.Name != "Louis Segond" {
+		t.Error("Meta ID and name should be used when present")
+	}
+	if r.Get("web") == nil {
+		t.Error("File name should be used as ID when meta is missing")
+	}
+}
+
+func TestLoadRegistryFromPath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	file := filepath.Join(t.TempDir(), "web.json")
+	os.WriteFile(file, []byte(`{"NT": {"John": {"1": {"1": "In the beginning was the Word"}}}}`), 0644)
+
+	r, err := LoadRegistry(file, "")
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	if r.Bibles[0].ID != "web" {
+		t.Errorf("External data should become the default translation, got %s", r.Bibles[0].ID)
+	}
+	if r.Get("kjv") == nil {
+		t.Error("The embedded translation should still be available")
+	}
+
+	if _, err := LoadRegistry(filepath.Join(t.TempDir(), "missing.json"), ""); err == nil {
+		t.Error("A missing data path should be reported")
+	}
+	if _, err := LoadRegistry(t.TempDir(), ""); err == nil {
+		t.Error("A directory without translations should be reported")
+	}
+}
+
+func TestLoadRegistrySkipsBadFiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".bible_translations")
+	os.Mkdir(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "kjv.json"), []byte(`{"NT": {"John": {"1": {"1": "In the beginning"}}}}`), 0644)
+	os.WriteFile(filepath.Join(dir, "web.json"), []byte(`{"NT": ["not", "books"]}`), 0644)
+	os.WriteFile(filepath.Join(dir, "ylt.json"), []byte(`{"NT": {"John": {"1": {"1": "In the beginning was the Word"}}}}`), 0644)
+
+	r, err := LoadRegistry("", "")
+	if err != nil {
+		t.Fatalf("Bad files in ~/.bible_translations should not stop loading: %v", err)
+	}
+	if r.Get("ylt") == nil || r.Get("web") != nil || r.Bibles[0].ID != "kjv" {
+		t.Errorf("Expected the embedded KJV and ylt, got %v", r.IDs())
+	}
+	if len(r.Skipped) != 2 {
+		t.Fatalf("Expected the duplicate and the malformed file to be skipped, got %v", r.Skipped)
+	}
+	for i, want := range []string{"kjv.json: translation 'kjv' is already loaded", "web.json: "} {
+		if !strings.HasPrefix(r.Skipped[i].Error(), want) {
+			t.Errorf("Skipped[%d] = %v, want it to start with %q", i, r.Skipped[i], want)
+		}
+	}
+}
+
+func TestRegistryLoadsOSIS(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "kjv.xml"), []byte(sampleOSIS), 0644)
+
+	r := NewRegistry()
+	if err := r.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	if b := r.Get("kjv"); b == nil || b.Book("Genesis") == nil {
+		t.Error("OSIS files should load alongside JSON translations")
+	}
+}
@@ -4,31 +4,406 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 )
 
 //go:embed data.json
 var embeddedData []byte
 
+// Bible holds one translation with its collections (testaments), books, chapters and verses in canonical order.
 type Bible struct {
-	OT Testament `json:"OT"`
-	NT Testament `json:"NT"`
+	ID            string // Short translation ID used by 'use' (e.g. "kjv")
+	Name          string
+	Language      string
+	Versification string // Verse-numbering scheme (see LookupVersification); empty means KJV
+	Collections   []*Collection
+}
+
+// Metadata is the optional "meta" block describing a translation file.
+type Metadata struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Language      string            `json:"language"`
+	Versification string            `json:"versification,omitempty"`
+	Collections   map[string]string `json:"collections,omitempty"` // Display names, e.g. {"DC": "Deuterocanon"}
+}
+
+type Collection struct {
+	ID    string
+	Name  string
+	Books []*Book
+}
+
+type Book struct {
+	Name       string // Name as it appears in the data (e.g. "1 John")
+	FullName   string
+	OSIS       string
+	Collection string
+	Chapters   []*Chapter
+}
+
+type Chapter struct {
+	Number int
+	Verses []*Verse
+}
+
+// Verse is stored in JSON as a plain string, or as an object when it carries study data.
+type Verse struct {
+	Number    int    `json:"-"`
+	Text      string `json:"text"`
+	Heading   string `json:"heading,omitempty"`   // Section heading printed before the verse
+	Paragraph bool   `json:"paragraph,omitempty"` // Verse opens a new paragraph
+	Through   int    `json:"through,omitempty"`   // Last verse of a bridge ("2-3") given as one
+	Notes     []Note `json:"notes,omitempty"`
+	Spans     []Span `json:"spans,omitempty"`
+}
+
+// Note is a footnote anchored after Offset bytes of the verse text.
+type Note struct {
+	Caller string `json:"caller,omitempty"`
+	Offset int    `json:"offset"`
+	Text   string `json:"text"`
+}
+
+// Span marks a run of verse text, e.g. Kind "wj" for the words of Jesus.
+type Span struct {
+	Kind  string `json:"kind"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// rawBible mirrors the JSON layout: Collection -> Book -> Chapter -> Verse. Every top-level key
+// other than "meta" is a collection ("OT", "DC", "NT", ...).
+type rawBible struct {
+	Meta        *Metadata
+	Collections map[string]rawBooks
+}
+
+type rawBooks = map[string]map[string]map[string]*Verse
+
+func (r *rawBible) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	r.Collections = make(map[string]rawBooks)
+	for key, value := range fields {
+		if key == "meta" {
+			if err := json.Unmarshal(value, &r.Meta); err != nil {
+				return fmt.Errorf("meta: %v", err)
+			}
+			continue
+		}
+		var books rawBooks
+		if err := json.Unmarshal(value, &books); err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		r.Collections[key] = books
+	}
+	return nil
+}
+
+func (r rawBible) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]any, len(r.Collections)+1)
+	if r.Meta != nil {
+		fields["meta"] = r.Meta
+	}
+	for id, books := range r.Collections {
+		fields[id] = books
+	}
+	return json.Marshal(fields)
 }
-type Testament map[string]Book
-type Book map[string]Chapter
-type Chapter map[string]string
 
 // ParseDatabase parses the embedded JSON
 func ParseDatabase(data []byte) (*Bible, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("data is empty")
 	}
-	var db Bible
-	if err := json.Unmarshal(data, &db); err != nil {
+	var raw rawBible
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return nil, fmt.Errorf("JSON structure mismatch: %v", err)
 	}
-	return &db, nil
+	if raw.Meta == nil {
+		raw.Meta = &Metadata{}
+	}
+
+	db := &Bible{ID: raw.Meta.ID, Name: raw.Meta.Name, Language: raw.Meta.Language, Versification: raw.Meta.Versification}
+	for _, id := range collectionOrder(raw.Collections) {
+		if len(raw.Collections[id]) == 0 {
+			continue
+		}
+		c, err := buildCollection(id, collectionName(id, raw.Meta.Collections), raw.Collections[id])
+		if err != nil {
+			return nil, err
+		}
+		db.Collections = append(db.Collections, c)
+	}
+	return db, nil
 }
 
+// LoadDatabase parses the embedded translation, which is the KJV unless its meta block says otherwise.
 func LoadDatabase() (*Bible, error) {
-	return ParseDatabase(embeddedData)
+	db, err := ParseDatabase(embeddedData)
+	if err != nil {
+		return nil, err
+	}
+	if db.ID == "" {
+		db.ID, db.Name, db.Language = "kjv", "King James Version", "en"
+	}
+	return db, nil
+}
+
+// MarshalJSON writes the same layout ParseDatabase reads, so imported translations round-trip.
+func (b *Bible) MarshalJSON() ([]byte, error) {
+	raw := rawBible{Collections: make(map[string]rawBooks)}
+	names := make(map[string]string)
+	for _, c := range b.Collections {
+		books := make(rawBooks)
+		for _, bk := range c.Books {
+			chapters := make(map[string]map[string]*Verse)
+			for _, ch := range bk.Chapters {
+				verses := make(map[string]*Verse)
+				for _, v := range ch.Verses {
+					verses[strconv.Itoa(v.Number)] = v
+				}
+				chapters[strconv.Itoa(ch.Number)] = verses
+			}
+			books[bk.Name] = chapters
+		}
+		raw.Collections[c.ID] = books
+		if c.Name != collectionName(c.ID, nil) {
+			names[c.ID] = c.Name
+		}
+	}
+	if b.ID != "" || b.Name != "" || b.Language != "" || b.Versification != "" || len(names) > 0 {
+		raw.Meta = &Metadata{ID: b.ID, Name: b.Name, Language: b.Language, Versification: b.Versification}
+		if len(names) > 0 {
+			raw.Meta.Collections = names
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON accepts either "text" or {"text": ..., "heading": ...}.
+func (v *Verse) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &v.Text)
+	}
+	type plain Verse
+	return json.Unmarshal(data, (*plain)(v))
+}
+
+// MarshalJSON keeps plain verses as strings so ordinary data stays compact.
+func (v *Verse) MarshalJSON() ([]byte, error) {
+	if v.Heading == "" && !v.Paragraph && v.Through == 0 && len(v.Notes) == 0 && len(v.Spans) == 0 {
+		return json.Marshal(v.Text)
+	}
+	type plain Verse
+	return json.Marshal((*plain)(v))
+}
+
+// --- BUILDING ---
+
+func buildCollection(id, name string, books map[string]map[string]map[string]*Verse) (*Collection, error) {
+	t := &Collection{ID: id, Name: name}
+	for bName, chapters := range books {
+		b := &Book{Name: bName, FullName: bName, Collection: id}
+		if info, _, ok := LookupCanon(bName); ok {
+			b.FullName = info.FullName
+			b.OSIS = info.OSIS
+		}
+		for cKey, verses := range chapters {
+			cNum, err := strconv.Atoi(cKey)
+			if err != nil {
+				return nil, fmt.Errorf("%s: chapter key %q is not a number", bName, cKey)
+			}
+			ch := &Chapter{Number: cNum}
+			for vKey, v := range verses {
+				vNum, err := strconv.Atoi(vKey)
+				if err != nil {
+					return nil, fmt.Errorf("%s %d: verse key %q is not a number", bName, cNum, vKey)
+				}
+				if v == nil {
+					v = &Verse{}
+				}
+				v.Number = vNum
+				ch.Verses = append(ch.Verses, v)
+			}
+			sort.Slice(ch.Verses, func(i, j int) bool { return ch.Verses[i].Number < ch.Verses[j].Number })
+			b.Chapters = append(b.Chapters, ch)
+		}
+		sort.Slice(b.Chapters, func(i, j int) bool { return b.Chapters[i].Number < b.Chapters[j].Number })
+		t.Books = append(t.Books, b)
+	}
+	// Map order is random: settle books outside the canon alphabetically first
+	sort.Slice(t.Books, func(i, j int) bool { return t.Books[i].Name < t.Books[j].Name })
+	sortBooks(t.Books)
+	return t, nil
+}
+
+// newBook creates an empty book, filling in canonical metadata from its OSIS ID when known.
+func newBook(name, osis string) *Book {
+	b := &Book{Name: name, FullName: name, OSIS: osis}
+	if info, _, ok := LookupCanon(osis); ok {
+		b.FullName = info.FullName
+		b.Collection = info.Collection
+		if b.Name == "" {
+			b.Name = info.Name
+		}
+	}
+	return b
+}
+
+// assemble groups books built by the importers into collections and orders everything canonically.
+// Books without a collection of their own go to the Old Testament.
+func assemble(books []*Book) *Bible {
+	grouped := make(map[string][]*Book)
+	for _, b := range books {
+		for _, ch := range b.Chapters {
+			sort.SliceStable(ch.Verses, func(i, j int) bool { return ch.Verses[i].Number < ch.Verses[j].Number })
+		}
+		sort.SliceStable(b.Chapters, func(i, j int) bool { return b.Chapters[i].Number < b.Chapters[j].Number })
+		if b.Collection == "" {
+			b.Collection = "OT"
+		}
+		grouped[b.Collection] = append(grouped[b.Collection], b)
+	}
+
+	db := &Bible{}
+	for _, id := range collectionOrder(grouped) {
+		sortBooks(grouped[id])
+		db.Collections = append(db.Collections, &Collection{ID: id, Name: collectionName(id, nil), Books: grouped[id]})
+	}
+	return db
+}
+
+// collectionOrder lists collection IDs in reading order: known collections first, others alphabetically.
+func collectionOrder[V any](collections map[string]V) []string {
+	ids := make([]string, 0, len(collections))
+	for id := range collections {
+		ids = append(ids, id)
+	}
+	rank := func(id string) int {
+		if _, i, ok := LookupCollection(id); ok {
+			return i
+		}
+		return len(Collections)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ri, rj := rank(ids[i]), rank(ids[j]); ri != rj {
+			return ri < rj
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// collectionName picks a collection's display name: the data's own, the known one, or the ID itself.
+func collectionName(id string, names map[string]string) string {
+	if name := names[id]; name != "" {
+		return name
+	}
+	if c, _, ok := LookupCollection(id); ok {
+		return c.Name
+	}
+	return id
+}
+
+// sortBooks orders books canonically; books missing from Canon follow in their current order.
+func sortBooks(books []*Book) {
+	rank := func(b *Book) int {
+		if _, i, ok := LookupCanon(b.OSIS); ok {
+			return i
+		}
+		if _, i, ok := LookupCanon(b.Name); ok {
+			return i
+		}
+		return len(Canon)
+	}
+	sort.SliceStable(books, func(i, j int) bool { return rank(books[i]) < rank(books[j]) })
+}
+
+// --- LOOKUPS ---
+
+// Collection returns the collection with the given ID ("OT", "DC", "NT") or name, ignoring case.
+// "AP" and "DC" find each other, since they hold the same books.
+func (b *Bible) Collection(id string) *Collection {
+	for _, c := range b.Collections {
+		if NormalizeName(c.ID) == NormalizeName(id) || NormalizeName(c.Name) == NormalizeName(id) {
+			return c
+		}
+	}
+	if info, _, ok := LookupCollection(id); ok {
+		for _, c := range b.Collections {
+			if SameCollection(c.ID, info.ID) {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// Books returns every book of the Bible in canonical order.
+func (b *Bible) Books() []*Book {
+	var all []*Book
+	for _, t := range b.Collections {
+		all = append(all, t.Books...)
+	}
+	return all
+}
+
+// Book finds a book by its data name, ignoring case and spaces.
+func (b *Bible) Book(name string) *Book {
+	for _, t := range b.Collections {
+		if bk := t.Book(name); bk != nil {
+			return bk
+		}
+	}
+	return nil
+}
+
+func (t *Collection) Book(name string) *Book {
+	key := NormalizeName(name)
+	for _, bk := range t.Books {
+		if NormalizeName(bk.Name) == key {
+			return bk
+		}
+	}
+	// Fall back to canonical identity so "Psalm" finds a book stored as "Psalms"
+	if info, _, ok := LookupCanon(name); ok {
+		for _, bk := range t.Books {
+			if bk.OSIS == info.OSIS {
+				return bk
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Book) Chapter(num int) *Chapter {
+	for _, ch := range b.Chapters {
+		if ch.Number == num {
+			return ch
+		}
+	}
+	return nil
+}
+
+// Verse returns the verse with the given number, or the bridge that holds it.
+func (c *Chapter) Verse(num int) *Verse {
+	for _, v := range c.Verses {
+		if v.Number == num || v.Number < num && num <= v.Through {
+			return v
+		}
+	}
+	return nil
+}
+
+// Label is the verse number as printed: "2", or "2-3" for a bridge.
+func (v *Verse) Label() string {
+	if v.Through > v.Number {
+		return fmt.Sprintf("%d-%d", v.Number, v.Through)
+	}
+	return strconv.Itoa(v.Number)
 }
@@ -0,0 +1,290 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// usfmBooks maps USFM book codes to OSIS IDs. Codes not listed here (front matter,
+// glossaries, ...) are skipped by the importer.
+var usfmBooks = map[string]string{
+	"GEN": "Gen", "EXO": "Exod", "LEV": "Lev", "NUM": "Num", "DEU": "Deut",
+	"JOS": "Josh", "JDG": "Judg", "RUT": "Ruth", "1SA": "1Sam", "2SA": "2Sam",
+	"1KI": "1Kgs", "2KI": "2Kgs", "1CH": "1Chr", "2CH": "2Chr", "EZR": "Ezra",
+	"NEH": "Neh", "EST": "Esth", "JOB": "Job", "PSA": "Ps", "PRO": "Prov",
+	"ECC": "Eccl", "SNG": "Song", "ISA": "Isa", "JER": "Jer", "LAM": "Lam",
+	"EZK": "Ezek", "DAN": "Dan", "HOS": "Hos", "JOL": "Joel", "AMO": "Amos",
+	"OBA": "Obad", "JON": "Jonah", "MIC": "Mic", "NAM": "Nah", "HAB": "Hab",
+	"ZEP": "Zeph", "HAG": "Hag", "ZEC": "Zech", "MAL": "Mal",
+	"MAT": "Matt", "MRK": "Mark", "LUK": "Luke", "JHN": "John", "ACT": "Acts",
+	"ROM": "Rom", "1CO": "1Cor", "2CO": "2Cor", "GAL": "Gal", "EPH": "Eph",
+	"PHP": "Phil", "COL": "Col", "1TH": "1Thess", "2TH": "2Thess", "1TI": "1Tim",
+	"2TI": "2Tim", "TIT": "Titus", "PHM": "Phlm", "HEB": "Heb", "JAS": "Jas",
+	"1PE": "1Pet", "2PE": "2Pet", "1JN": "1John", "2JN": "2John", "3JN": "3John",
+	"JUD": "Jude", "REV": "Rev",
+	"TOB": "Tob", "JDT": "Jdt", "ESG": "AddEsth", "WIS": "Wis", "SIR": "Sir", "BAR": "Bar",
+	"LJE": "EpJer", "S3Y": "PrAzar", "SUS": "Sus", "BEL": "Bel", "1MA": "1Macc", "2MA": "2Macc",
+	"3MA": "3Macc", "4MA": "4Macc", "1ES": "1Esd", "2ES": "2Esd", "MAN": "PrMan", "PS2": "AddPs",
+}
+
+// usfmToken splits USFM into closing markers, opening markers (with their single trailing
+// space) and text runs.
+var usfmToken = regexp.MustCompile(`\\(\+?[a-z]+[0-9]*\*)|\\(\+?[a-z]+[0-9]*)[ \t]?|[^\\]+`)
+
+// Marker families, keyed by the marker name without its level digit.
+var (
+	usfmParagraphs = map[string]bool{"p": true, "m": true, "pi": true, "pm": true, "pmo": true, "pc": true, "mi": true, "cls": true}
+	usfmHeadings   = map[string]bool{"s": true, "ms": true, "d": true}
+	usfmLineSkips  = map[string]bool{"ide": true, "toc": true, "toca": true, "mt": true, "mte": true, "imt": true, "is": true,
+		"ip": true, "im": true, "io": true, "iot": true, "ie": true, "rem": true, "sts": true, "usfm": true, "cl": true,
+		"r": true, "mr": true, "sr": true, "cp": true}
+	usfmHidden   = map[string]bool{"va": true, "ca": true, "vp": true, "fig": true, "rq": true}
+	usfmNoteText = map[string]bool{"ft": true, "fq": true, "fqa": true, "fk": true, "fl": true, "fw": true, "fp": true, "fv": true}
+)
+
+// ParseUSFM converts USFM books (one file's content per argument) into a Bible, keeping
+// section headings, paragraph breaks, footnotes and words-of-Jesus spans.
+func ParseUSFM(files ...[]byte) (*Bible, error) {
+	var books []*Book
+	for _, data := range files {
+		book, err := parseUSFMBook(string(data))
+		if err != nil {
+			return nil, err
+		}
+		if book != nil {
+			books = append(books, book)
+		}
+	}
+	if len(books) == 0 {
+		return nil, fmt.Errorf("no USFM books found")
+	}
+	return assemble(books), nil
+}
+
+// LoadUSFMDir parses every .usfm/.sfm file in dir as one translation named after the directory.
+func LoadUSFMDir(dir string) (*Bible, error) {
+	paths := usfmFiles(dir)
+	files := make([][]byte, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, data)
+	}
+	db, err := ParseUSFM(files...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filepath.Base(dir), err)
+	}
+	db.ID = strings.ToLower(filepath.Base(dir))
+	db.Name = strings.ToUpper(db.ID)
+	return db, nil
+}
+
+// usfmFiles lists the USFM files of a directory in name order.
+func usfmFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && isUSFM(e.Name()) {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	return paths
+}
+
+func isUSFM(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".usfm" || ext == ".sfm"
+}
+
+// --- PARSER ---
+
+type usfmParser struct {
+	verseBuilder
+	code string
+
+	lineMarker string // Marker whose argument runs to the end of the line
+
+	note      *Note
+	noteField string
+	inXref    bool
+
+	charStack []string // Open character markers (wj, add, w, ...)
+}
+
+func parseUSFMBook(src string) (*Book, error) {
+	p := &usfmParser{verseBuilder: newVerseBuilder()}
+	for _, m := range usfmToken.FindAllStringSubmatch(src, -1) {
+		if tag := m[1] + m[2]; tag != "" {
+			p.marker(tag)
+			continue
+		}
+		if err := p.text(m[0]); err != nil {
+			return nil, err
+		}
+	}
+	p.finishVerse()
+	if p.book == nil || p.book.OSIS == "" {
+		return nil, nil
+	}
+	return p.book, nil
+}
+
+func (p *usfmParser) marker(tag string) {
+	tag = strings.TrimPrefix(tag, "+")
+	closing := strings.HasSuffix(tag, "*")
+	name := strings.TrimRight(strings.TrimSuffix(tag, "*"), "0123456789")
+
+	switch {
+	case p.inXref:
+		if name == "x" && closing {
+			p.inXref = false
+		}
+	case p.note != nil:
+		if (name == "f" || name == "fe") && closing {
+			p.addNote(*p.note)
+			p.note, p.noteField = nil, ""
+		} else if !closing {
+			p.noteField = name
+		}
+	case name == "x" && !closing:
+		p.inXref = true
+	case (name == "f" || name == "fe") && !closing:
+		p.note = &Note{Offset: p.noteOffset()}
+		p.noteField = "caller"
+	case name == "id" || name == "c" || name == "v" || name == "h" || usfmHeadings[name] || usfmLineSkips[name]:
+		p.lineMarker = name
+	case usfmParagraphs[name]:
+		p.markParagraph()
+	case name == "q" || name == "qr" || name == "qc" || name == "li" || name == "b":
+		p.space()
+	case closing:
+		p.closeChar(name)
+	default:
+		p.openChar(name)
+	}
+}
+
+func (p *usfmParser) text(s string) error {
+	if p.inXref {
+		return nil
+	}
+	if p.note != nil {
+		p.noteText(s)
+		return nil
+	}
+
+	if p.lineMarker != "" {
+		arg, rest, hadNewline := strings.Cut(s, "\n")
+		marker := p.lineMarker
+		p.lineMarker = ""
+		leftover, err := p.lineArgument(marker, arg)
+		if err != nil {
+			return err
+		}
+		if hadNewline {
+			leftover += "\n"
+		}
+		s = leftover + rest
+	}
+	p.verseText(s)
+	return nil
+}
+
+// lineArgument handles markers whose argument runs to the end of the line. For \v the
+// text after the verse number is handed back as verse text.
+func (p *usfmParser) lineArgument(marker, arg string) (string, error) {
+	word := firstWord(arg)
+	switch marker {
+	case "id":
+		p.code = strings.ToUpper(word)
+		if osis, ok := usfmBooks[p.code]; ok {
+			p.book = newBook("", osis)
+		} else {
+			p.book = &Book{Name: p.code}
+		}
+	case "h":
+		if arg = strings.TrimSpace(arg); p.book != nil && arg != "" {
+			p.book.Name = arg
+		}
+	case "c":
+		if p.book == nil {
+			return "", fmt.Errorf("\\c before \\id")
+		}
+		num, err := strconv.Atoi(word)
+		if err != nil {
+			return "", fmt.Errorf("%s: invalid chapter number %q", p.code, arg)
+		}
+		p.startChapter(num)
+	case "v":
+		if p.chapter == nil {
+			return "", fmt.Errorf("%s: \\v %s outside of a chapter", p.code, word)
+		}
+		num, through, err := verseNumbers(word)
+		if err != nil {
+			return "", fmt.Errorf("%s %d: invalid verse number %q", p.code, p.chapter.Number, word)
+		}
+		p.startVerse(num, through)
+		return strings.TrimPrefix(strings.TrimLeft(arg, " \t"), word), nil
+	default:
+		if usfmHeadings[marker] {
+			p.addHeading(arg)
+		}
+	}
+	return "", nil
+}
+
+func (p *usfmParser) noteText(s string) {
+	if p.noteField == "caller" {
+		caller := firstWord(s)
+		p.note.Caller = caller
+		s = strings.TrimPrefix(strings.TrimSpace(s), caller)
+		p.noteField = "ft"
+	}
+	if usfmNoteText[p.noteField] {
+		p.note.Text += s
+	}
+}
+
+func (p *usfmParser) openChar(name string) {
+	p.charStack = append(p.charStack, name)
+	if name == "wj" {
+		p.openWordsOfJesus()
+	}
+}
+
+func (p *usfmParser) closeChar(name string) {
+	for i := len(p.charStack) - 1; i >= 0; i-- {
+		if p.charStack[i] == name {
+			p.charStack = p.charStack[:i]
+			break
+		}
+	}
+	if name == "wj" {
+		p.closeWordsOfJesus()
+	}
+}
+
+// verseText filters character-level markup before handing text to the builder.
+func (p *usfmParser) verseText(s string) {
+	for _, open := range p.charStack {
+		if usfmHidden[open] {
+			return
+		}
+	}
+	if len(p.charStack) > 0 && p.charStack[len(p.charStack)-1] == "w" {
+		// \w word|lemma="..."\w* keeps only the word
+		if word, _, ok := strings.Cut(s, "|"); ok {
+			s = word
+		}
+	}
+	p.appendText(s)
+}
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sampleJohn = `\id JHN World English Bible
+\h John
+\toc1 The Good News According to John
+\mt1 The Good News According to John
+\c 3
+\s1 Jesus and Nicodemus
+\p
+\v 16 \wj For God so loved the world, that he gave his one and only Son,\wj* \f + \fr 3:16 \ft “one and only” is from the Greek “monogenes”.\f* \wj that whoever believes in him should not perish, but have eternal life.\wj*
+\v 17 \wj For God didn’t send his Son into the world to judge the world,
+\q1 but that the world should be saved through him.\wj*
+\p
+\v 18 He who believes in him is not judged.\x + \xo 3:18 \xt John 5:24\x*
+`
+
+const sampleGenesis = `\id GEN
+\c 1
+\p
+\v 1 In the beginning, God \add created\add* the heavens and the earth.
+\v 2-3 The earth was formless and empty. God said, “Let there be light,” and there was light.
+`
+
+func TestParseUSFM(t *testing.T) {
+	db, err := ParseUSFM([]byte(sampleJohn), []byte(sampleGenesis))
+	if err != nil {
+		t.Fatalf("ParseUSFM failed: %v", err)
+	}
+
+	if db.Books()[0].Name != "Genesis" {
+		t.Error("Books should be ordered canonically regardless of file order")
+	}
+
+	john := db.Collection("NT").Book("John")
+	if john == nil || john.OSIS != "John" {
+		t.Fatal("Expected John in the NT with its OSIS ID")
+	}
+	ch := john.Chapter(3)
+
+	v16 := ch.Verse(16)
+	want := "For God so loved the world, that he gave his one and only Son, that whoever believes in him should not perish, but have eternal life."
+	if v16.Text != want {
+		t.Errorf("Verse text mismatch.\n got: %q\nwant: %q", v16.Text, want)
+	}
+	if v16.Heading != "Jesus and Nicodemus" || !v16.Paragraph {
+		t.Errorf("Expected heading and paragraph on 3:16, got %q / %v", v16.Heading, v16.Paragraph)
+	}
+	if len(v16.Notes) != 1 || v16.Notes[0].Caller != "+" || v16.Notes[0].Text != "“one and only” is from the Greek “monogenes”." {
+		t.Errorf("Footnote not captured: %+v", v16.Notes)
+	}
+	if v16.Text[:v16.Notes[0].Offset] != "For God so loved the world, that he gave his one and only Son," {
+		t.Errorf("Footnote anchored at the wrong offset: %d", v16.Notes[0].Offset)
+	}
+	if len(v16.Spans) != 2 || v16.Text[v16.Spans[0].Start:v16.Spans[0].End] != "For God so loved the world, that he gave his one and only Son," {
+		t.Errorf("Words of Jesus spans wrong: %+v", v16.Spans)
+	}
+
+	v17 := ch.Verse(17)
+	if v17.Text != "For God didn’t send his Son into the world to judge the world, but that the world should be saved through him." {
+		t.Errorf("Poetry lines should join with a space, got %q", v17.Text)
+	}
+	if v17.Paragraph {
+		t.Error("3:17 does not start a paragraph")
+	}
+
+	v18 := ch.Verse(18)
+	if v18.Text != "He who believes in him is not judged." || !v18.Paragraph {
+		t.Errorf("Cross references should be dropped, got %q", v18.Text)
+	}
+
+	gen := db.Book("Genesis").Chapter(1).Verse(1)
+	if gen.Text != "In the beginning, God created the heavens and the earth." {
+		t.Errorf("Character markers should keep their text, got %q", gen.Text)
+	}
+}
+
+func TestParseUSFMVerseBridge(t *testing.T) {
+	src := "\\id PHP\n\\c 4\n\\v 1 Therefore, my brothers,\n\\v 2-3 I exhort Euodia, and I exhort Syntyche.\n\\v 4 Rejoice in the Lord always!\n"
+	db, err := ParseUSFM([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseUSFM failed: %v", err)
+	}
+	ch := db.Book("Philippians").Chapter(4)
+	v := ch.Verse(2)
+	if v == nil || v.Through != 3 || v.Label() != "2-3" || v.Text != "I exhort Euodia, and I exhort Syntyche." {
+		t.Fatalf("Bridge should keep its text on its first verse and record its last: %+v", v)
+	}
+	if ch.Verse(3) != v || len(ch.Verses) != 3 {
+		t.Errorf("Verse 3 should be found in the bridge, not repeated: %+v", ch.Verses)
+	}
+
+	data, _ := json.Marshal(db)
+	if r := Validate(data); r.Errors() != 0 || r.Warnings() != 2 {
+		t.Errorf("Only the missing chapters and books should be warned about, not verse 3: %+v", r.Issues)
+	}
+}
+
+func TestParseUSFMErrors(t *testing.T) {
+	if _, err := ParseUSFM([]byte("\\id GEN\n\\v 1 Verse without a chapter")); err == nil {
+		t.Error("A verse outside a chapter should be an error")
+	}
+	if _, err := ParseUSFM([]byte("\\id FRT\n\\p Front matter only")); err == nil {
+		t.Error("Input without any Bible book should be an error")
+	}
+}
+
+func TestUSFMRoundTripsThroughJSON(t *testing.T) {
+	db, _ := ParseUSFM([]byte(sampleJohn))
+	db.ID = "web"
+
+	data, err := json.Marshal(db)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	back, err := ParseDatabase(data)
+	if err != nil {
+		t.Fatalf("Exported JSON does not load: %v", err)
+	}
+	v := back.Book("John").Chapter(3).Verse(16)
+	if back.ID != "web" || v.Heading != "Jesus and Nicodemus" || len(v.Notes) != 1 {
+		t.Errorf("Study data lost in the JSON round-trip: %+v", v)
+	}
+}
+
+func TestRegistryLoadsUSFMDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "web")
+	os.Mkdir(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "44JHN.usfm"), []byte(sampleJohn), 0644)
+
+	r := NewRegistry()
+	if err := r.LoadDir(filepath.Dir(dir)); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	if b := r.Get("web"); b == nil || b.Book("John") == nil {
+		t.Error("A subdirectory of USFM books should load as one translation")
+	}
+}
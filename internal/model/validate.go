@@ -0,0 +1,325 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Issue severities. Errors break reading; warnings are worth a look.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is one problem found in a translation file.
+type Issue struct {
+	Severity string
+	Where    string // e.g. "NT/John 3:16"
+	Message  string
+}
+
+// CollectionCount summarizes one collection of a validated file.
+type CollectionCount struct {
+	ID       string
+	Books    int
+	Chapters int
+	Verses   int
+}
+
+// Report is the result of Validate.
+type Report struct {
+	Issues []Issue
+	Counts []CollectionCount
+}
+
+// Errors counts the issues of severity error.
+func (r *Report) Errors() int { return r.count(SeverityError) }
+
+// Warnings counts the issues of severity warning.
+func (r *Report) Warnings() int { return r.count(SeverityWarning) }
+
+func (r *Report) count(severity string) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *Report) add(severity, where, format string, args ...any) {
+	r.Issues = append(r.Issues, Issue{Severity: severity, Where: where, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate checks a JSON translation for the problems ParseDatabase lets through or stops at
+// the first of: duplicated keys and books, non-numeric chapter/verse keys, gaps in numbering,
+// empty verses, books unknown to the canon or filed under the wrong collection, and books
+// missing from a testament.
+func Validate(data []byte) *Report {
+	r := &Report{}
+	if len(data) == 0 {
+		r.add(SeverityError, "", "data is empty")
+		return r
+	}
+
+	dups, err := duplicateKeys(data)
+	if err != nil {
+		r.add(SeverityError, "", "invalid JSON: %v", err)
+		return r
+	}
+	for _, path := range dups {
+		r.add(SeverityError, path, "duplicate key; only the last one is kept")
+	}
+
+	var raw rawBible
+	if err := json.Unmarshal(data, &raw); err != nil {
+		r.add(SeverityError, "", "JSON structure mismatch: %v", err)
+		return r
+	}
+
+	seen := make(map[string]string) // OSIS ID -> where it was first found
+	ids := collectionOrder(raw.Collections)
+	for _, id := range ids {
+		books := raw.Collections[id]
+		if _, _, ok := LookupCollection(id); !ok {
+			r.add(SeverityWarning, id, "unknown collection (known: OT, DC, AP, NT)")
+		}
+		count := CollectionCount{ID: id, Books: len(books)}
+		for _, name := range validationOrder(books) {
+			where := id + "/" + name
+			if info, _, ok := LookupCanon(name); !ok {
+				r.add(SeverityWarning, where, "unknown book name")
+			} else {
+				if first, dup := seen[info.OSIS]; dup {
+					r.add(SeverityError, where, "duplicates %s", first)
+				}
+				seen[info.OSIS] = where
+				if !SameCollection(info.Collection, id) {
+					r.add(SeverityWarning, where, "belongs to the %s", info.Collection)
+				}
+			}
+			c, v := r.checkBook(where, books[name])
+			count.Chapters += c
+			count.Verses += v
+		}
+		r.Counts = append(r.Counts, count)
+	}
+
+	// Only the testaments are expected to be complete; deuterocanonical selections vary by tradition
+	for _, id := range ids {
+		if !SameCollection(id, "OT") && !SameCollection(id, "NT") {
+			continue
+		}
+		var missing []string
+		for _, info := range Canon {
+			if _, ok := seen[info.OSIS]; !ok && SameCollection(info.Collection, id) {
+				missing = append(missing, info.Name)
+			}
+		}
+		if len(missing) > 0 {
+			r.add(SeverityWarning, id, "%d books missing: %s", len(missing), strings.Join(missing, ", "))
+		}
+	}
+
+	if _, err := ParseDatabase(data); err != nil {
+		r.add(SeverityError, "", "does not load: %v", err)
+	}
+	return r
+}
+
+// ValidateFormat checks a translation file in any of the Formats. Files in other formats than
+// JSON are imported first: what stops the importer is reported, and so are the books, chapters
+// and verses given twice, which the JSON layout would silently merge. The imported text is then
+// checked like JSON.
+func ValidateFormat(data []byte, format string) *Report {
+	if strings.EqualFold(format, FormatJSON) {
+		return Validate(data)
+	}
+
+	r := &Report{}
+	db, err := parse(data, format, r)
+	if err != nil {
+		r.add(SeverityError, "", "does not load: %v", err)
+		return r
+	}
+	r.checkRepeats(db)
+	converted, err := json.Marshal(db)
+	if err != nil {
+		r.add(SeverityError, "", "does not convert to JSON: %v", err)
+		return r
+	}
+	checked := Validate(converted)
+	r.Issues = append(r.Issues, checked.Issues...)
+	r.Counts = checked.Counts
+	return r
+}
+
+// checkRepeats reports the books of a collection, chapters of a book and verses of a chapter
+// that share a name or number.
+func (r *Report) checkRepeats(db *Bible) {
+	for _, c := range db.Collections {
+		books := make(map[string]bool)
+		for _, b := range c.Books {
+			where := c.ID + "/" + b.Name
+			if books[b.Name] {
+				r.add(SeverityError, where, "book is repeated")
+			}
+			books[b.Name] = true
+
+			chapters := make(map[int]bool)
+			for _, ch := range b.Chapters {
+				if chapters[ch.Number] {
+					r.add(SeverityError, fmt.Sprintf("%s %d", where, ch.Number), "chapter is repeated")
+				}
+				chapters[ch.Number] = true
+
+				verses := make(map[int]bool)
+				for _, v := range ch.Verses {
+					if verses[v.Number] {
+						r.add(SeverityError, fmt.Sprintf("%s %d:%d", where, ch.Number, v.Number), "verse is repeated")
+					}
+					verses[v.Number] = true
+				}
+			}
+		}
+	}
+}
+
+// checkBook reports key, numbering and text problems in one book and returns its chapter and verse counts.
+func (r *Report) checkBook(where string, chapters map[string]map[string]*Verse) (int, int) {
+	var chapNums []int
+	verseCount := 0
+	for _, cKey := range sortedKeys(chapters) {
+		cNum, err := strconv.Atoi(cKey)
+		if err != nil || cNum < 1 {
+			r.add(SeverityError, where, "chapter key %q is not a number", cKey)
+			continue
+		}
+		chapNums = append(chapNums, cNum)
+
+		var verseNums, bridged []int
+		for _, vKey := range sortedKeys(chapters[cKey]) {
+			ref := fmt.Sprintf("%s %d:%s", where, cNum, vKey)
+			vNum, err := strconv.Atoi(vKey)
+			if err != nil || vNum < 1 {
+				r.add(SeverityError, ref, "verse key is not a number")
+				continue
+			}
+			verseNums = append(verseNums, vNum)
+			v := chapters[cKey][vKey]
+			if v == nil || strings.TrimSpace(v.Text) == "" {
+				r.add(SeverityError, ref, "empty verse text")
+				continue
+			}
+			for n := vNum + 1; n <= v.Through; n++ {
+				bridged = append(bridged, n) // Given with vNum, so not missing
+			}
+		}
+		verseCount += len(verseNums)
+		if len(verseNums) == 0 {
+			r.add(SeverityError, fmt.Sprintf("%s %d", where, cNum), "chapter has no verses")
+		}
+		r.checkGaps(fmt.Sprintf("%s %d", where, cNum), "verse", append(verseNums, bridged...))
+	}
+	r.checkGaps(where, "chapter", chapNums)
+	return len(chapNums), verseCount
+}
+
+// checkGaps warns about numbers missing from 1..max.
+func (r *Report) checkGaps(where, unit string, nums []int) {
+	sort.Ints(nums)
+	next := 1
+	for _, n := range nums {
+		switch {
+		case n == next+1:
+			r.add(SeverityWarning, where, "%s %d missing", unit, next)
+		case n > next:
+			r.add(SeverityWarning, where, "%ss %d-%d missing", unit, next, n-1)
+		}
+		next = n + 1
+	}
+}
+
+// duplicateKeys lists the paths ("NT/John/3/16") of keys that appear twice in the same JSON object.
+// encoding/json silently keeps the last one, so they are only visible in the token stream.
+func duplicateKeys(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var dups []string
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			keys := make(map[string]bool)
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key := keyTok.(string)
+				child := strings.TrimPrefix(path+"/"+key, "/")
+				if keys[key] {
+					dups = append(dups, child)
+				}
+				keys[key] = true
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		case json.Delim('['):
+			for dec.More() {
+				if err := walk(path); err != nil {
+					return err
+				}
+			}
+		default:
+			return nil
+		}
+		_, err = dec.Token() // Closing delimiter
+		return err
+	}
+
+	if err := walk(""); err != nil {
+		return nil, err
+	}
+	return dups, nil
+}
+
+// validationOrder lists book names canonically, unknown ones last.
+func validationOrder(books rawBooks) []string {
+	names := sortedKeys(books)
+	rank := func(name string) int {
+		if _, i, ok := LookupCanon(name); ok {
+			return i
+		}
+		return len(Canon)
+	}
+	sort.SliceStable(names, func(i, j int) bool { return rank(names[i]) < rank(names[j]) })
+	return names
+}
+
+// sortedKeys orders map keys numerically where they are numbers, alphabetically otherwise.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	data := `{"OT": {"Genesis": {"1": {"1": "a", "3": "", "x": "b"}, "3": {"1": "c"}}, "Genesys": {"1": {"1": "z"}}},
+	          "NT": {"John": {"3": {"16": "d", "16": "e"}}, "Psalm": {"1": {"1": "p"}}, "Psalms": {"1": {"1": "q"}}}}`
+	report := Validate([]byte(data))
+
+	want := []struct {
+		severity, where, message string
+	}{
+		{SeverityError, "NT/John/3/16", "duplicate key"},
+		{SeverityError, "OT/Genesis 1:3", "empty verse text"},
+		{SeverityError, "OT/Genesis 1:x", "not a number"},
+		{SeverityWarning, "OT/Genesis 1", "verse 2 missing"},
+		{SeverityWarning, "OT/Genesis", "chapter 2 missing"},
+		{SeverityWarning, "NT/John 3", "verses 1-15 missing"},
+		{SeverityWarning, "OT/Genesys", "unknown book"},
+		{SeverityWarning, "NT/Psalm", "belongs to the OT"},
+		{SeverityError, "NT/Psalms", "duplicates NT/Psalm"},
+		{SeverityWarning, "NT", "26 books missing"},
+		{SeverityError, "", "does not load"},
+	}
+	for _, w := range want {
+		found := false
+		for _, issue := range report.Issues {
+			if issue.Severity == w.severity && issue.Where == w.where && strings.Contains(issue.Message, w.message) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Missing %s at %q: %q\nGot: %+v", w.severity, w.where, w.message, report.Issues)
+		}
+	}
+
+	if c := report.Counts; len(c) != 2 || c[0] != (CollectionCount{"OT", 2, 3, 4}) || c[1] != (CollectionCount{"NT", 3, 3, 3}) {
+		t.Errorf("Per-collection counts wrong: %+v", c)
+	}
+}
+
+func TestValidateCleanData(t *testing.T) {
+	db, err := ParseUSFM([]byte(sampleGenesis))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(db)
+	report := Validate(data)
+	if report.Errors() != 0 {
+		t.Errorf("A well-formed file should have no errors: %+v", report.Issues)
+	}
+	if report.Warnings() != 1 {
+		t.Errorf("Only the books missing from the OT should be warned about: %+v", report.Issues)
+	}
+}
+
+func TestValidateBrokenJSON(t *testing.T) {
+	for _, data := range []string{"", `{"OT": {`, `{"OT": []}`} {
+		if r := Validate([]byte(data)); r.Errors() != 1 {
+			t.Errorf("%q should give exactly one error, got %+v", data, r.Issues)
+		}
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	tests := []struct {
+		name, format, data string
+		want               []Issue
+	}{
+		{
+			"CSV rows", FormatCSV,
+			"John,3,16,a\nJohn,x,17,b\nJohn,3\nJn,3,16,c\nJohn,3,18,d\n",
+			[]Issue{
+				{SeverityError, "line 2", `chapter "x" is not a number`},
+				{SeverityError, "line 3", "got 2 columns"},
+				{SeverityError, "line 4", "John 3:16 is repeated (first on line 1)"},
+				{SeverityWarning, "NT/John 3", "verse 17 missing"},
+			},
+		},
+		{
+			"USFM repeats", FormatUSFM,
+			"\\id GEN\n\\c 1\n\\v 1 a\n\\v 2 b\n\\v 2 c\n\\c 1\n\\v 3 d\n",
+			[]Issue{
+				{SeverityError, "OT/Genesis 1:2", "verse is repeated"},
+				{SeverityError, "OT/Genesis 1", "chapter is repeated"},
+			},
+		},
+		{
+			"OSIS numbers", FormatOSIS,
+			`<osis><div type="book" osisID="Gen"><chapter osisID="Gen.1"><verse osisID="Gen.1.x">a</verse></chapter></div></osis>`,
+			[]Issue{{SeverityError, "", `does not load: OSIS: invalid verse osisID "Gen.1.x"`}},
+		},
+	}
+	for _, tt := range tests {
+		report := ValidateFormat([]byte(tt.data), tt.format)
+		for _, w := range tt.want {
+			found := false
+			for _, issue := range report.Issues {
+				if issue.Severity == w.Severity && issue.Where == w.Where && strings.Contains(issue.Message, w.Message) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("%s: missing %s at %q: %q\nGot: %+v", tt.name, w.Severity, w.Where, w.Message, report.Issues)
+			}
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package model
+
+import "strings"
+
+// VerseRef points at a verse by OSIS book ID, chapter and verse.
+type VerseRef struct {
+	Book    string
+	Chapter int
+	Verse   int
+}
+
+// verseRule says that verses From..To (To 0: to the end) of a chapter in some scheme are the
+// KJV verses starting at KJVChapter:KJVVerse, in order. KJVVerse 0 means they have no KJV
+// equivalent (e.g. Psalm titles, which the KJV prints unnumbered).
+type verseRule struct {
+	book              string
+	chapter, from, to int
+	kjvChapter        int
+	kjvVerse          int
+}
+
+// Versification is a verse-numbering tradition. References convert through KJV numbering;
+// anything not covered by a rule is numbered the same as in the KJV.
+type Versification struct {
+	ID    string
+	Name  string
+	rules []verseRule
+}
+
+// Known versification schemes. These cover the common chapter and verse boundary differences,
+// not every minor split.
+var (
+	KJV     = &Versification{ID: "kjv", Name: "King James / English"}
+	Hebrew  = &Versification{ID: "mt", Name: "Hebrew (Masoretic)", rules: hebrewRules()}
+	LXX     = &Versification{ID: "lxx", Name: "Septuagint / Orthodox", rules: lxxRules()}
+	Vulgate = &Versification{ID: "vulgate", Name: "Latin Vulgate", rules: vulgateRules()}
+
+	Versifications = []*Versification{KJV, Hebrew, LXX, Vulgate}
+)
+
+// LookupVersification finds a scheme by ID or a common alias ("hebrew", "bhs", "septuagint", "vulg").
+func LookupVersification(id string) (*Versification, bool) {
+	switch strings.ToLower(strings.TrimSpace(id)) {
+	case "", "kjv", "english", "kjva":
+		return KJV, true
+	case "mt", "hebrew", "bhs", "masoretic":
+		return Hebrew, true
+	case "lxx", "septuagint", "orthodox":
+		return LXX, true
+	case "vulgate", "vulg", "latin":
+		return Vulgate, true
+	}
+	return nil, false
+}
+
+// ToKJV converts a reference of this scheme into KJV numbering. ok is false when the verse
+// has no KJV counterpart.
+func (s *Versification) ToKJV(ref VerseRef) (VerseRef, bool) {
+	for _, r := range s.rules {
+		if r.book == ref.Book && r.chapter == ref.Chapter && ref.Verse >= r.from && (r.to == 0 || ref.Verse <= r.to) {
+			if r.kjvVerse == 0 {
+				return VerseRef{}, false
+			}
+			return VerseRef{ref.Book, r.kjvChapter, r.kjvVerse + ref.Verse - r.from}, true
+		}
+	}
+	return ref, true
+}
+
+// FromKJV converts a KJV reference into this scheme's numbering.
+func (s *Versification) FromKJV(ref VerseRef) (VerseRef, bool) {
+	for _, r := range s.rules {
+		if r.kjvVerse == 0 || r.book != ref.Book || r.kjvChapter != ref.Chapter || ref.Verse < r.kjvVerse {
+			continue
+		}
+		if r.to != 0 && ref.Verse > r.kjvVerse+r.to-r.from {
+			continue
+		}
+		return VerseRef{ref.Book, r.chapter, r.from + ref.Verse - r.kjvVerse}, true
+	}
+	return ref, true
+}
+
+// MapVerse converts a reference from one scheme to another.
+func MapVerse(ref VerseRef, from, to *Versification) (VerseRef, bool) {
+	if from == to {
+		return ref, true
+	}
+	kjv, ok := from.ToKJV(ref)
+	if !ok {
+		return VerseRef{}, false
+	}
+	return to.FromKJV(kjv)
+}
+
+// Scheme returns the translation's versification, KJV when unset or unknown.
+func (b *Bible) Scheme() *Versification {
+	if s, ok := LookupVersification(b.Versification); ok {
+		return s
+	}
+	return KJV
+}
+
+// Verse finds a verse by reference in this translation's own numbering.
+func (b *Bible) Verse(ref VerseRef) *Verse {
+	if bk := b.Book(ref.Book); bk != nil {
+		if ch := bk.Chapter(ref.Chapter); ch != nil {
+			return ch.Verse(ref.Verse)
+		}
+	}
+	return nil
+}
+
+// --- SCHEMES ---
+
+// Psalms whose titles the Hebrew numbers as verse 1 (or verses 1-2), pushing the text down.
+var (
+	psalmTitles    = []int{3, 4, 5, 6, 7, 8, 9, 12, 18, 19, 20, 21, 22, 30, 31, 34, 36, 38, 39, 40, 41, 42, 44, 45, 46, 47, 48, 49, 53, 55, 56, 57, 58, 59, 61, 62, 63, 64, 65, 67, 68, 69, 70, 75, 76, 77, 80, 81, 83, 84, 85, 88, 89, 92, 102, 108, 140, 142}
+	psalmTitlesTwo = []int{51, 52, 54, 60}
+)
+
+func psalmTitleVerses(psalm int) int {
+	for _, p := range psalmTitlesTwo {
+		if p == psalm {
+			return 2
+		}
+	}
+	for _, p := range psalmTitles {
+		if p == psalm {
+			return 1
+		}
+	}
+	if psalm == 13 {
+		return 1
+	}
+	return 0
+}
+
+// Joel 3-4 in the Hebrew and Greek are KJV Joel 2:28-3:21.
+var joelRules = []verseRule{
+	{"Joel", 3, 1, 0, 2, 28},
+	{"Joel", 4, 1, 0, 3, 1},
+}
+
+func hebrewRules() []verseRule {
+	rules := []verseRule{
+		{"Gen", 32, 1, 1, 31, 55},
+		{"Gen", 32, 2, 0, 32, 1},
+		{"Exod", 7, 26, 29, 8, 1},
+		{"Exod", 8, 1, 0, 8, 5},
+		{"Lev", 5, 20, 26, 6, 1},
+		{"Lev", 6, 1, 0, 6, 8},
+		{"Num", 17, 1, 15, 16, 36},
+		{"Num", 17, 16, 0, 17, 1},
+		{"Num", 30, 1, 1, 29, 40},
+		{"Num", 30, 2, 0, 30, 1},
+		{"Deut", 13, 1, 1, 12, 32},
+		{"Deut", 13, 2, 0, 13, 1},
+		{"1Kgs", 5, 1, 14, 4, 21},
+		{"1Kgs", 5, 15, 0, 5, 1},
+		{"Isa", 8, 23, 23, 9, 1},
+		{"Isa", 9, 1, 0, 9, 2},
+		{"Hos", 2, 1, 2, 1, 10},
+		{"Hos", 2, 3, 0, 2, 1},
+		{"Hos", 12, 1, 1, 11, 12},
+		{"Hos", 12, 2, 0, 12, 1},
+		{"Jonah", 2, 1, 1, 1, 17},
+		{"Jonah", 2, 2, 0, 2, 1},
+		{"Mic", 4, 14, 14, 5, 1},
+		{"Mic", 5, 1, 0, 5, 2},
+		{"Dan", 3, 31, 33, 4, 1},
+		{"Dan", 4, 1, 0, 4, 4},
+		{"Dan", 6, 1, 1, 5, 31},
+		{"Dan", 6, 2, 0, 6, 1},
+		{"Mal", 3, 19, 0, 4, 1},
+		// Psalm 13: the Hebrew joins KJV 13:5-6 into 13:6
+		{"Ps", 13, 1, 1, 13, 0},
+		{"Ps", 13, 2, 5, 13, 1},
+		{"Ps", 13, 6, 6, 13, 5},
+	}
+	rules = append(rules, joelRules...)
+	for psalm := 1; psalm <= 150; psalm++ {
+		if psalm == 13 {
+			continue
+		}
+		rules = append(rules, psalmRules(psalm, 1, 0, psalm, 1)...)
+	}
+	return rules
+}
+
+// psalmRules maps verses from..to of a psalm numbered as in the Hebrew (titles counted) to the
+// KJV. chapter/from are where those verses sit in the scheme, hebrewPsalm/hebrewVerse where they
+// start in the Hebrew.
+func psalmRules(chapter, from, to, hebrewPsalm, hebrewVerse int) []verseRule {
+	titles := max(psalmTitleVerses(hebrewPsalm)-hebrewVerse+1, 0)
+	if titles == 0 && chapter == hebrewPsalm && from == hebrewVerse {
+		return nil // Same numbering as the KJV
+	}
+	var rules []verseRule
+	if titles > 0 {
+		rules = append(rules, verseRule{"Ps", chapter, from, from + titles - 1, hebrewPsalm, 0})
+		from += titles
+		hebrewVerse += titles
+	}
+	return append(rules, verseRule{"Ps", chapter, from, to, hebrewPsalm, hebrewVerse - psalmTitleVerses(hebrewPsalm)})
+}
+
+// greekPsalms maps the Greek (and Latin) psalm numbering, whose verses follow the Hebrew, onto the KJV.
+// The Greek joins Psalms 9-10 and 114-115, and splits 116 and 147.
+func greekPsalms() []verseRule {
+	var rules []verseRule
+	add := func(chapter, from, to, hebrewPsalm, hebrewVerse int) {
+		rules = append(rules, psalmRules(chapter, from, to, hebrewPsalm, hebrewVerse)...)
+	}
+	for h := 1; h <= 150; h++ {
+		switch {
+		case h == 9:
+			add(9, 1, 21, 9, 1)
+		case h == 10:
+			add(9, 22, 0, 10, 1)
+		case h == 114:
+			add(113, 1, 8, 114, 1)
+		case h == 115:
+			add(113, 9, 0, 115, 1)
+		case h == 116:
+			add(114, 1, 9, 116, 1)
+			add(115, 1, 0, 116, 10)
+		case h == 147:
+			add(146, 1, 11, 147, 1)
+			add(147, 1, 0, 147, 12)
+		case h > 10 && h < 148:
+			add(h-1, 1, 0, h, 1)
+		default:
+			add(h, 1, 0, h, 1)
+		}
+	}
+	return rules
+}
+
+func lxxRules() []verseRule {
+	rules := []verseRule{
+		// Malachi 3:19-24, with Moses (KJV 4:4) moved to the end
+		{"Mal", 3, 19, 21, 4, 1},
+		{"Mal", 3, 22, 23, 4, 5},
+		{"Mal", 3, 24, 24, 4, 4},
+		// The doxology closes Romans 14
+		{"Rom", 14, 24, 26, 16, 25},
+	}
+	rules = append(rules, joelRules...)
+	return append(rules, greekPsalms()...)
+}
+
+func vulgateRules() []verseRule {
+	rules := []verseRule{
+		// 3 John 14 is split in two
+		{"3John", 1, 14, 14, 1, 14},
+		{"3John", 1, 15, 15, 1, 14},
+	}
+	return append(rules, greekPsalms()...)
+}
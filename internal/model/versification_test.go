@@ -0,0 +1,78 @@
+package model
+
+import "testing"
+
+func TestMapVerse(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to *Versification
+		in, want VerseRef
+		ok       bool
+	}{
+		{"Same scheme", KJV, KJV, VerseRef{"John", 3, 16}, VerseRef{"John", 3, 16}, true},
+		{"Malachi 4 in Hebrew", KJV, Hebrew, VerseRef{"Mal", 4, 5}, VerseRef{"Mal", 3, 23}, true},
+		{"Malachi 3:19 to KJV", Hebrew, KJV, VerseRef{"Mal", 3, 19}, VerseRef{"Mal", 4, 1}, true},
+		{"Malachi before the split", Hebrew, KJV, VerseRef{"Mal", 3, 18}, VerseRef{"Mal", 3, 18}, true},
+		{"Joel 3 in Hebrew", Hebrew, KJV, VerseRef{"Joel", 3, 1}, VerseRef{"Joel", 2, 28}, true},
+		{"Psalm title offset", KJV, Hebrew, VerseRef{"Ps", 23, 1}, VerseRef{"Ps", 23, 1}, true},
+		{"Psalm with a title", KJV, Hebrew, VerseRef{"Ps", 3, 1}, VerseRef{"Ps", 3, 2}, true},
+		{"Psalm with a two-verse title", KJV, Hebrew, VerseRef{"Ps", 51, 10}, VerseRef{"Ps", 51, 12}, true},
+		{"Title has no KJV verse", Hebrew, KJV, VerseRef{"Ps", 51, 2}, VerseRef{}, false},
+		{"Psalm 23 is 22 in the Vulgate", KJV, Vulgate, VerseRef{"Ps", 23, 1}, VerseRef{"Ps", 22, 1}, true},
+		{"Vulgate 50 is the Miserere", Vulgate, KJV, VerseRef{"Ps", 50, 3}, VerseRef{"Ps", 51, 1}, true},
+		{"Greek joins Psalms 9 and 10", KJV, LXX, VerseRef{"Ps", 10, 1}, VerseRef{"Ps", 9, 22}, true},
+		{"Greek splits Psalm 116", KJV, LXX, VerseRef{"Ps", 116, 10}, VerseRef{"Ps", 115, 1}, true},
+		{"Greek splits Psalm 147", LXX, KJV, VerseRef{"Ps", 147, 1}, VerseRef{"Ps", 147, 12}, true},
+		{"Latin to Hebrew", Vulgate, Hebrew, VerseRef{"Ps", 22, 1}, VerseRef{"Ps", 23, 1}, true},
+		{"3 John 15 in the Vulgate", Vulgate, KJV, VerseRef{"3John", 1, 15}, VerseRef{"3John", 1, 14}, true},
+		{"3 John 14 back to the Vulgate", KJV, Vulgate, VerseRef{"3John", 1, 14}, VerseRef{"3John", 1, 14}, true},
+		{"Doxology of Romans", KJV, LXX, VerseRef{"Rom", 16, 26}, VerseRef{"Rom", 14, 25}, true},
+		{"Greek Malachi order", LXX, KJV, VerseRef{"Mal", 3, 24}, VerseRef{"Mal", 4, 4}, true},
+		{"Exodus 8 in Hebrew", KJV, Hebrew, VerseRef{"Exod", 8, 1}, VerseRef{"Exod", 7, 26}, true},
+	}
+	for _, tt := range tests {
+		got, ok := MapVerse(tt.in, tt.from, tt.to)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("%s: MapVerse(%v, %s, %s) = %v, %v; want %v, %v", tt.name, tt.in, tt.from.ID, tt.to.ID, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestVersificationRoundTrip(t *testing.T) {
+	// The first verses of every psalm must come back unchanged through each scheme, except where
+	// the Hebrew joins two KJV verses (13:5-6). Psalm 114, joined to 115 in the Greek, has 8.
+	for _, s := range Versifications {
+		for psalm := 1; psalm <= 150; psalm++ {
+			for verse := 1; verse <= 8; verse++ {
+				if s == Hebrew && psalm == 13 && verse == 6 {
+					continue
+				}
+				ref := VerseRef{"Ps", psalm, verse}
+				there, ok := s.FromKJV(ref)
+				if !ok {
+					t.Fatalf("%s: %v has no counterpart", s.ID, ref)
+				}
+				if back, ok := s.ToKJV(there); !ok || back != ref {
+					t.Fatalf("%s: %v -> %v -> %v", s.ID, ref, there, back)
+				}
+			}
+		}
+	}
+}
+
+func TestBibleScheme(t *testing.T) {
+	db, err := ParseDatabase([]byte(`{"meta": {"id": "vg", "versification": "vulgate"}, "OT": {"Psalms": {"22": {"1": "Dominus regit me"}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Scheme() != Vulgate {
+		t.Fatalf("meta.versification should select the scheme, got %s", db.Scheme().ID)
+	}
+	ref, _ := MapVerse(VerseRef{"Ps", 23, 1}, KJV, db.Scheme())
+	if v := db.Verse(ref); v == nil || v.Text != "Dominus regit me" {
+		t.Errorf("Psalm 23:1 should resolve to the Vulgate's 22:1, got %v", ref)
+	}
+	if (&Bible{}).Scheme() != KJV {
+		t.Error("Translations default to KJV numbering")
+	}
+}
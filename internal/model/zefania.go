@@ -0,0 +1,132 @@
+package model
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseZefania reads a Zefania XML Bible (<XMLBIBLE>/<BIBLEBOOK bnumber>/<CHAPTER cnumber>/<VERS vnumber>).
+// Book numbers 1-66 follow the Protestant canon; later books are matched by name. Captions become
+// section headings and <NOTE>s footnotes.
+func ParseZefania(data []byte) (*Bible, error) {
+	b := newVerseBuilder()
+	db := &Bible{}
+	var books []*Book
+	var info string // INFORMATION child being read
+	var note *Note
+	var caption *strings.Builder
+	skip := false // Inside a book outside the canon table
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Zefania: %v", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := strings.ToUpper(t.Name.Local)
+			switch {
+			case name == "XMLBIBLE":
+				db.Name = osisAttr(t, "biblename")
+			case name == "INFORMATION":
+				info = "INFORMATION"
+			case info != "":
+				info = strings.ToLower(t.Name.Local)
+			case name == "BIBLEBOOK":
+				b.finishVerse()
+				b.book, b.chapter = nil, nil
+				info, ok := zefaniaBook(t)
+				skip = !ok
+				if ok {
+					b.book = newBook(osisAttr(t, "bname"), info.OSIS)
+					books = append(books, b.book)
+				}
+			case skip:
+			case name == "CHAPTER":
+				num, err := strconv.Atoi(osisAttr(t, "cnumber"))
+				if err != nil || b.book == nil {
+					return nil, fmt.Errorf("Zefania: invalid chapter %q", osisAttr(t, "cnumber"))
+				}
+				b.startChapter(num)
+			case name == "VERS":
+				num, through, err := verseNumbers(osisAttr(t, "vnumber"))
+				if err != nil || b.chapter == nil {
+					return nil, fmt.Errorf("Zefania: invalid verse %q", osisAttr(t, "vnumber"))
+				}
+				b.startVerse(num, through)
+			case name == "CAPTION":
+				caption = &strings.Builder{}
+			case name == "NOTE":
+				note = &Note{Offset: b.noteOffset()}
+			case name == "BR":
+				b.space()
+			}
+
+		case xml.EndElement:
+			name := strings.ToUpper(t.Name.Local)
+			switch {
+			case name == "INFORMATION":
+				info = ""
+			case info != "":
+				info = "INFORMATION"
+			case name == "VERS":
+				b.finishVerse()
+			case name == "CAPTION" && caption != nil:
+				b.addHeading(caption.String())
+				caption = nil
+			case name == "NOTE" && note != nil:
+				b.addNote(*note)
+				note = nil
+			}
+
+		case xml.CharData:
+			text := string(t)
+			switch {
+			case info == "identifier":
+				db.ID = strings.ToLower(strings.TrimSpace(text))
+			case info == "language":
+				db.Language = strings.ToLower(strings.TrimSpace(text))
+			case info == "title" && db.Name == "":
+				db.Name = strings.TrimSpace(text)
+			case info != "" || skip:
+			case caption != nil:
+				caption.WriteString(text)
+			case note != nil:
+				note.Text += text
+			default:
+				b.appendText(text)
+			}
+		}
+	}
+	b.finishVerse()
+
+	if len(books) == 0 {
+		return nil, fmt.Errorf("Zefania: no books found")
+	}
+	bible := assemble(books)
+	bible.ID, bible.Name, bible.Language = db.ID, db.Name, db.Language
+	return bible, nil
+}
+
+// zefaniaBook resolves a BIBLEBOOK by number, or by its name or short name for books past Revelation.
+func zefaniaBook(el xml.StartElement) (BookInfo, bool) {
+	num, _ := strconv.Atoi(osisAttr(el, "bnumber"))
+	if info, ok := numberedBook(num); ok {
+		return info, true
+	}
+	for _, name := range []string{osisAttr(el, "bname"), osisAttr(el, "bsname")} {
+		if info, _, ok := LookupCanon(name); ok && name != "" {
+			return info, true
+		}
+	}
+	return BookInfo{}, false
+}
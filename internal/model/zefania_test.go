@@ -0,0 +1,89 @@
+package model
+
+import "testing"
+
+const sampleZefania = `<?xml version="1.0" encoding="utf-8"?>
+<XMLBIBLE xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" biblename="Reina-Valera 1909">
+  <INFORMATION>
+    <title>Reina-Valera 1909</title>
+    <identifier>RV1909</identifier>
+    <language>SPA</language>
+  </INFORMATION>
+  <BIBLEBOOK bnumber="43" bname="Juan" bsname="Jn">
+    <CHAPTER cnumber="3">
+      <CAPTION vref="16">El amor de Dios</CAPTION>
+      <VERS vnumber="16">Porque de tal manera amó Dios al mundo,<NOTE type="x-studynote">O, unigénito</NOTE> que ha dado á su Hijo unigénito.</VERS>
+      <VERS vnumber="17">Porque no envió Dios á su Hijo<BR art="x-nl"/>al mundo.</VERS>
+    </CHAPTER>
+  </BIBLEBOOK>
+  <BIBLEBOOK bnumber="1" bname="Génesis">
+    <CHAPTER cnumber="1">
+      <VERS vnumber="1">EN el principio crió Dios los cielos y la tierra.</VERS>
+    </CHAPTER>
+  </BIBLEBOOK>
+  <BIBLEBOOK bnumber="70" bname="Odas">
+    <CHAPTER cnumber="1"><VERS vnumber="1">Skipped.</VERS></CHAPTER>
+  </BIBLEBOOK>
+</XMLBIBLE>`
+
+func TestParseZefania(t *testing.T) {
+	db, err := ParseZefania([]byte(sampleZefania))
+	if err != nil {
+		t.Fatalf("ParseZefania failed: %v", err)
+	}
+	if db.ID != "rv1909" || db.Name != "Reina-Valera 1909" || db.Language != "spa" {
+		t.Errorf("Information not read: %q %q %q", db.ID, db.Name, db.Language)
+	}
+
+	books := db.Books()
+	if len(books) != 2 || books[0].Name != "Génesis" || books[1].OSIS != "John" || books[1].Collection != "NT" {
+		t.Fatalf("bnumber should map onto the canon in order, got %+v", books)
+	}
+	if db.Book("John") == nil {
+		t.Error("Localized books should still be found by their English name")
+	}
+
+	v16 := db.Book("Juan").Chapter(3).Verse(16)
+	if v16.Text != "Porque de tal manera amó Dios al mundo, que ha dado á su Hijo unigénito." {
+		t.Errorf("Verse text mismatch: %q", v16.Text)
+	}
+	if v16.Heading != "El amor de Dios" || len(v16.Notes) != 1 || v16.Notes[0].Text != "O, unigénito" {
+		t.Errorf("Caption or note lost: %+v", v16)
+	}
+	if v17 := db.Book("Juan").Chapter(3).Verse(17); v17.Text != "Porque no envió Dios á su Hijo al mundo." {
+		t.Errorf("<BR> should become a space: %q", v17.Text)
+	}
+}
+
+func TestParseZefaniaErrors(t *testing.T) {
+	if _, err := ParseZefania([]byte(`<XMLBIBLE><BIBLEBOOK bnumber="1"><CHAPTER cnumber="x">`)); err == nil {
+		t.Error("A non-numeric chapter should be an error")
+	}
+	if _, err := ParseZefania([]byte(`<XMLBIBLE></XMLBIBLE>`)); err == nil {
+		t.Error("A document without books should be an error")
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		path string
+		data string
+		want string
+	}{
+		{"kjv.json", "", FormatJSON},
+		{"JHN.SFM", "", FormatUSFM},
+		{"kjv.xml", sampleOSIS, FormatOSIS},
+		{"rv.xml", sampleZefania, FormatZefania},
+		{"bible.csv", "", FormatCSV},
+		{"bible.tsv", "", FormatTSV},
+		{"README.md", "", ""},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.path, []byte(tt.data)); got != tt.want {
+			t.Errorf("DetectFormat(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+	if _, err := Parse([]byte("{}"), "docx"); err == nil {
+		t.Error("An unknown format should be an error")
+	}
+}
@@ -0,0 +1,269 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultDistance is how far apart NEAR without a number lets its words be.
+const DefaultDistance = 5
+
+// ParseError reports where a query stopped making sense.
+type ParseError struct {
+	Input string
+	Pos   int // Byte offset into Input
+	Msg   string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokPhrase
+	tokAnd
+	tokOr
+	tokNot
+	tokNear
+	tokOpen
+	tokClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	n    int // NEAR distance
+}
+
+// HasOperators reports whether a search uses the query language: AND, OR, NOT or NEAR (in
+// capitals), parentheses, quotes or a -word. Anything else is plain text to look for.
+func HasOperators(s string) bool {
+	if strings.ContainsAny(s, `()"`) {
+		return true
+	}
+	for _, f := range strings.Fields(s) {
+		if f == "AND" || f == "OR" || f == "NOT" || strings.HasPrefix(f, "NEAR") && isNear(f) || len(f) > 1 && f[0] == '-' {
+			return true
+		}
+	}
+	return false
+}
+
+// lex splits a query into words, quoted phrases, operators and parentheses.
+func lex(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			toks = append(toks, token{kind: tokOpen, text: "(", pos: start})
+			i++
+		case r == ')':
+			toks = append(toks, token{kind: tokClose, text: ")", pos: start})
+			i++
+		case r == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, &ParseError{Input: s, Pos: start, Msg: "unclosed quote"}
+			}
+			toks = append(toks, token{kind: tokPhrase, text: s[i+1 : i+1+end], pos: start})
+			i += end + 2
+		case r == '-' && i+1 < len(s) && !unicode.IsSpace(rune(s[i+1])):
+			toks = append(toks, token{kind: tokNot, text: "-", pos: start})
+			i++
+		default:
+			for i < len(s) {
+				r, size := utf8.DecodeRuneInString(s[i:])
+				if unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' {
+					break
+				}
+				i += size
+			}
+			toks = append(toks, word(s[start:i], start))
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(s)}), nil
+}
+
+// word reads a bare word, which may be an operator.
+func word(text string, pos int) token {
+	switch {
+	case text == "AND":
+		return token{kind: tokAnd, text: text, pos: pos}
+	case text == "OR":
+		return token{kind: tokOr, text: text, pos: pos}
+	case text == "NOT":
+		return token{kind: tokNot, text: text, pos: pos}
+	case isNear(text):
+		n := DefaultDistance
+		if d, ok := strings.CutPrefix(text, "NEAR/"); ok {
+			n, _ = strconv.Atoi(d)
+		}
+		return token{kind: tokNear, text: text, pos: pos, n: n}
+	}
+	return token{kind: tokWord, text: text, pos: pos}
+}
+
+func isNear(text string) bool {
+	if text == "NEAR" {
+		return true
+	}
+	d, ok := strings.CutPrefix(text, "NEAR/")
+	n, err := strconv.Atoi(d)
+	return ok && err == nil && n >= 0
+}
+
+type parser struct {
+	input string
+	toks  []token
+	i     int
+}
+
+// Parse reads a query. From loosest to tightest: OR, AND (or words side by side), NOT and -,
+// then NEAR/n, which joins words or phrases.
+func Parse(s string) (*Query, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	ps := &parser{input: s, toks: toks}
+	if ps.peek().kind == tokEOF {
+		return nil, ps.errorf(ps.peek(), "empty query")
+	}
+	root, err := ps.or()
+	if err != nil {
+		return nil, err
+	}
+	if tok := ps.peek(); tok.kind != tokEOF {
+		return nil, ps.errorf(tok, "unexpected %q", tok.text)
+	}
+	return &Query{Root: root}, nil
+}
+
+func (ps *parser) peek() token { return ps.toks[ps.i] }
+
+func (ps *parser) next() token {
+	tok := ps.toks[ps.i]
+	if tok.kind != tokEOF {
+		ps.i++
+	}
+	return tok
+}
+
+func (ps *parser) errorf(tok token, format string, args ...any) error {
+	return &ParseError{Input: ps.input, Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (ps *parser) or() (Node, error) {
+	left, err := ps.and()
+	if err != nil {
+		return nil, err
+	}
+	for ps.peek().kind == tokOr {
+		ps.next()
+		right, err := ps.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{left, right}
+	}
+	return left, nil
+}
+
+func (ps *parser) and() (Node, error) {
+	left, err := ps.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch ps.peek().kind {
+		case tokOr, tokClose, tokEOF:
+			return left, nil
+		case tokAnd:
+			ps.next()
+		}
+		right, err := ps.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{left, right}
+	}
+}
+
+func (ps *parser) unary() (Node, error) {
+	if ps.peek().kind == tokNot {
+		ps.next()
+		n, err := ps.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{n}, nil
+	}
+	return ps.near()
+}
+
+func (ps *parser) near() (Node, error) {
+	left, err := ps.primary()
+	if err != nil {
+		return nil, err
+	}
+	for ps.peek().kind == tokNear {
+		op := ps.next()
+		right, err := ps.primary()
+		if err != nil {
+			return nil, err
+		}
+		if !isWords(left) || !isWords(right) {
+			return nil, ps.errorf(op, "%s joins words or phrases", op.text)
+		}
+		left = &Near{left, right, op.n}
+	}
+	return left, nil
+}
+
+func (ps *parser) primary() (Node, error) {
+	tok := ps.next()
+	switch tok.kind {
+	case tokWord, tokPhrase:
+		w := words(tok.text)
+		switch {
+		case len(w) == 0:
+			return nil, ps.errorf(tok, "no words in %q", tok.text)
+		case len(w) == 1:
+			return &Term{w[0]}, nil
+		}
+		return &Phrase{w}, nil
+	case tokOpen:
+		n, err := ps.or()
+		if err != nil {
+			return nil, err
+		}
+		if ps.peek().kind != tokClose {
+			return nil, ps.errorf(ps.peek(), "expected %q", ")")
+		}
+		ps.next()
+		return n, nil
+	case tokEOF:
+		return nil, ps.errorf(tok, "expected a word")
+	}
+	return nil, ps.errorf(tok, "expected a word, found %q", tok.text)
+}
+
+func isWords(n Node) bool {
+	switch n.(type) {
+	case *Term, *Phrase:
+		return true
+	}
+	return false
+}
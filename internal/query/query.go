@@ -0,0 +1,238 @@
+// Package query parses search queries such as `grace AND faith`, `love -hate`, `"living water"`
+// or `(peace OR rest) NOT war` into a tree, and matches them against the words of a verse.
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/search"
+)
+
+// Node is one part of a parsed query.
+type Node interface {
+	// match reports whether the verse matches; pos lists where each of its words occurs.
+	match(pos map[string][]int) bool
+	String() string
+}
+
+// Term matches a verse holding a word.
+type Term struct {
+	Word string // Folded as in the search index
+}
+
+// Phrase matches a verse holding words one right after the other ("living water").
+type Phrase struct {
+	Words []string
+}
+
+// And matches verses matching both sides; it is also what words side by side mean.
+type And struct {
+	Left, Right Node
+}
+
+// Or matches verses matching either side.
+type Or struct {
+	Left, Right Node
+}
+
+// Not matches verses that do not match its operand ("NOT war", "-hate").
+type Not struct {
+	Node Node
+}
+
+// Near matches verses where its sides, words or phrases, occur at most Distance words apart, in
+// either order ("faith NEAR/5 works").
+type Near struct {
+	Left, Right Node
+	Distance    int
+}
+
+// Query is a parsed search query.
+type Query struct {
+	Root Node
+}
+
+// Match reports whether a verse matches, given its folded words in order (see search.Tokenize).
+func (q *Query) Match(words []string) bool {
+	pos := make(map[string][]int)
+	for i, w := range words {
+		pos[w] = append(pos[w], i)
+	}
+	return q.Root.match(pos)
+}
+
+// Anchors lists words at least one of which every matching verse holds, so a search need only
+// look at the verses that have one. It is nil when a verse can match without any ("NOT war").
+func (q *Query) Anchors() []string {
+	return anchors(q.Root)
+}
+
+// Terms lists the words a verse is searched for, for highlighting; words under NOT are left out.
+func (q *Query) Terms() []string {
+	var words []string
+	var walk func(n Node)
+	walk = func(n Node) {
+		switch n := n.(type) {
+		case *Term:
+			words = append(words, n.Word)
+		case *Phrase:
+			words = append(words, n.Words...)
+		case *And:
+			walk(n.Left)
+			walk(n.Right)
+		case *Or:
+			walk(n.Left)
+			walk(n.Right)
+		case *Near:
+			walk(n.Left)
+			walk(n.Right)
+		}
+	}
+	walk(q.Root)
+	return words
+}
+
+func (q *Query) String() string {
+	return q.Root.String()
+}
+
+// MapWords returns the query with each of its words passed through f, such as a stemmer, so that
+// it matches verse words passed through f too.
+func (q *Query) MapWords(f func(string) string) *Query {
+	var walk func(n Node) Node
+	walk = func(n Node) Node {
+		switch n := n.(type) {
+		case *Term:
+			return &Term{f(n.Word)}
+		case *Phrase:
+			words := make([]string, len(n.Words))
+			for i, w := range n.Words {
+				words[i] = f(w)
+			}
+			return &Phrase{words}
+		case *And:
+			return &And{walk(n.Left), walk(n.Right)}
+		case *Or:
+			return &Or{walk(n.Left), walk(n.Right)}
+		case *Not:
+			return &Not{walk(n.Node)}
+		case *Near:
+			return &Near{walk(n.Left), walk(n.Right), n.Distance}
+		}
+		return n
+	}
+	return &Query{Root: walk(q.Root)}
+}
+
+func anchors(n Node) []string {
+	switch n := n.(type) {
+	case *Term:
+		return []string{n.Word}
+	case *Phrase:
+		return []string{n.Words[0]}
+	case *Near:
+		return anchors(n.Left)
+	case *And:
+		if words := anchors(n.Left); words != nil {
+			return words
+		}
+		return anchors(n.Right)
+	case *Or:
+		left, right := anchors(n.Left), anchors(n.Right)
+		if left == nil || right == nil {
+			return nil
+		}
+		return append(left, right...)
+	}
+	return nil
+}
+
+func (t *Term) match(pos map[string][]int) bool {
+	return len(pos[t.Word]) > 0
+}
+
+func (p *Phrase) match(pos map[string][]int) bool {
+	return len(p.starts(pos)) > 0
+}
+
+// starts lists where the phrase begins in the verse.
+func (p *Phrase) starts(pos map[string][]int) []int {
+	var found []int
+	for _, start := range pos[p.Words[0]] {
+		if p.at(pos, start) {
+			found = append(found, start)
+		}
+	}
+	return found
+}
+
+func (p *Phrase) at(pos map[string][]int, start int) bool {
+	for i, w := range p.Words[1:] {
+		if !contains(pos[w], start+1+i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *And) match(pos map[string][]int) bool { return a.Left.match(pos) && a.Right.match(pos) }
+func (o *Or) match(pos map[string][]int) bool  { return o.Left.match(pos) || o.Right.match(pos) }
+func (n *Not) match(pos map[string][]int) bool { return !n.Node.match(pos) }
+
+func (n *Near) match(pos map[string][]int) bool {
+	left, right := spans(n.Left, pos), spans(n.Right, pos)
+	for _, l := range left {
+		for _, r := range right {
+			// The gap between the end of one side and the start of the other
+			if gap := max(r[0]-l[1], l[0]-r[1]); gap <= n.Distance {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// spans lists the word ranges [first, last] where a term or phrase occurs.
+func spans(n Node, pos map[string][]int) [][2]int {
+	var found [][2]int
+	switch n := n.(type) {
+	case *Term:
+		for _, p := range pos[n.Word] {
+			found = append(found, [2]int{p, p})
+		}
+	case *Phrase:
+		for _, p := range n.starts(pos) {
+			found = append(found, [2]int{p, p + len(n.Words) - 1})
+		}
+	}
+	return found
+}
+
+func contains(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Term) String() string   { return t.Word }
+func (p *Phrase) String() string { return `"` + strings.Join(p.Words, " ") + `"` }
+func (a *And) String() string    { return "(" + a.Left.String() + " AND " + a.Right.String() + ")" }
+func (o *Or) String() string     { return "(" + o.Left.String() + " OR " + o.Right.String() + ")" }
+func (n *Not) String() string    { return "NOT " + n.Node.String() }
+func (n *Near) String() string {
+	return fmt.Sprintf("(%s NEAR/%d %s)", n.Left, n.Distance, n.Right)
+}
+
+// words folds a term of the query the way the index folds verse text. "LORD's" stays one word;
+// "faith,works" makes two.
+func words(text string) []string {
+	var out []string
+	for _, tok := range search.Tokenize(text) {
+		out = append(out, tok.Word)
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/search"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"grace AND faith", "(grace AND faith)"},
+		{"grace faith", "(grace AND faith)"},
+		{"love -hate", "(love AND NOT hate)"},
+		{`"living water"`, `"living water"`},
+		{"(peace OR rest) NOT war", "((peace OR rest) AND NOT war)"},
+		{"a OR b c", "(a OR (b AND c))"},
+		{"faith NEAR/3 works", "(faith NEAR/3 works)"},
+		{`"the lord" NEAR shepherd`, `("the lord" NEAR/5 shepherd)`},
+		{"NOT NOT sin", "NOT NOT sin"},
+		{"LORD's", "lord's"},
+		{"Élie", "elie"},
+		{"well-known", `"well known"`},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("%q: %v", tt.input, err)
+			continue
+		}
+		if got := q.String(); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "empty query at position 1"},
+		{`"living water`, "unclosed quote at position 1"},
+		{"(peace OR rest", `expected ")" at position 15`},
+		{"grace AND", "expected a word at position 10"},
+		{"grace )", `unexpected ")" at position 7`},
+		{"(a OR b) NEAR/2 c", "NEAR/2 joins words or phrases at position 10"},
+		{"!!!", `no words in "!!!" at position 1`},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var perr *ParseError
+		if !errors.As(err, &perr) || err.Error() != tt.want {
+			t.Errorf("%q: got %v, want %s", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	verse := func(text string) []string {
+		var words []string
+		for _, tok := range search.Tokenize(text) {
+			words = append(words, tok.Word)
+		}
+		return words
+	}
+	john4 := verse("Jesus answered and said unto her, If thou knewest the gift of God, he would have given thee living water.")
+	james2 := verse("Ye see then how that by works a man is justified, and not by faith only.")
+
+	tests := []struct {
+		query string
+		words []string
+		want  bool
+	}{
+		{`"living water"`, john4, true},
+		{`"water living"`, john4, false},
+		{"gift AND god", john4, true},
+		{"gift -god", john4, false},
+		{"(peace OR gift) NOT war", john4, true},
+		{"faith NEAR/8 works", james2, true},
+		{"faith NEAR/7 works", james2, false},
+		{`"by faith" NEAR/7 works`, james2, true},
+		{"grace OR mercy", james2, false},
+		{"NOT grace", james2, true},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.query, err)
+		}
+		if got := q.Match(tt.words); got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestAnchorsAndTerms(t *testing.T) {
+	tests := []struct {
+		query   string
+		anchors []string
+		terms   []string
+	}{
+		{"grace AND faith", []string{"grace"}, []string{"grace", "faith"}},
+		{"peace OR rest", []string{"peace", "rest"}, []string{"peace", "rest"}},
+		{"-war peace", []string{"peace"}, []string{"peace"}},
+		{"NOT war", nil, nil},
+		{"peace OR NOT war", nil, []string{"peace"}},
+		{`"living water" NEAR well`, []string{"living"}, []string{"living", "water", "well"}},
+		{`"by faith" OR works`, []string{"by", "works"}, []string{"by", "faith", "works"}}, // The phrase is left whole
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.query, err)
+		}
+		if got := q.Anchors(); !reflect.DeepEqual(got, tt.anchors) {
+			t.Errorf("%q: anchors %v, want %v", tt.query, got, tt.anchors)
+		}
+		if got := q.Terms(); !reflect.DeepEqual(got, tt.terms) {
+			t.Errorf("%q: terms %v, want %v", tt.query, got, tt.terms)
+		}
+	}
+}
+
+func TestMapWords(t *testing.T) {
+	q, err := Parse(`loveth -"hated him" OR (love NEAR/2 brethren)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upper := q.MapWords(strings.ToUpper)
+	if got, want := upper.String(), `((LOVETH AND NOT "HATED HIM") OR (LOVE NEAR/2 BRETHREN))`; got != want {
+		t.Errorf("MapWords = %s, want %s", got, want)
+	}
+	if strings.Contains(q.String(), "LOVE") {
+		t.Errorf("MapWords should leave the query alone, got %s", q)
+	}
+}
+
+func TestHasOperators(t *testing.T) {
+	for s, want := range map[string]bool{
+		"love":             false,
+		"God so loved":     false,
+		"faith and works":  false,
+		"faith AND works":  true,
+		"love -hate":       true,
+		`"living water"`:   true,
+		"(peace)":          true,
+		"faith NEAR/5 law": true,
+		"NEARLY":           false,
+		"well-known":       false,
+	} {
+		if got := HasOperators(s); got != want {
+			t.Errorf("HasOperators(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,432 @@
+package reference
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokNum
+	tokColon
+	tokDash
+	tokComma
+	tokSemi
+	tokPlus
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// lex splits a reference into words, numbers and punctuation. "1jn3:16" reads as 1, jn, 3, :, 16.
+func lex(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		start := i
+		switch {
+		case unicode.IsSpace(r) || r == '"':
+			// Quotes around an argument ("john 3:16") are only spacing
+			i += size
+			continue
+		case r >= '0' && r <= '9':
+			for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+				i++
+			}
+			toks = append(toks, token{tokNum, s[start:i], start})
+			continue
+		case unicode.IsLetter(r):
+			for i < len(s) {
+				r, size := utf8.DecodeRuneInString(s[i:])
+				if !unicode.IsLetter(r) && r != '.' && r != '\'' {
+					break
+				}
+				i += size
+			}
+			toks = append(toks, token{tokWord, s[start:i], start})
+			continue
+		}
+
+		kind, ok := map[rune]tokenKind{
+			':': tokColon, '.': tokColon,
+			'-': tokDash, '–': tokDash, '—': tokDash,
+			',': tokComma, ';': tokSemi, '+': tokPlus,
+		}[r]
+		if !ok {
+			return nil, &ParseError{Input: s, Pos: start, Msg: fmt.Sprintf("unexpected %q", r)}
+		}
+		toks = append(toks, token{kind, string(r), start})
+		i += size
+	}
+	return append(toks, token{kind: tokEOF, pos: len(s)}), nil
+}
+
+// parser walks the tokens of one input. cur is the book and chapter bare numbers refer to.
+type parser struct {
+	*Parser
+	input string
+	toks  []token
+	i     int
+	cur   Ref
+	out   []Range
+}
+
+// Parse reads one or more references separated by ";", "+" or "and". A group that names no book
+// continues the previous one, or the Context for the first group.
+func (p *Parser) Parse(s string) ([]Range, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	ps := &parser{Parser: p, input: s, toks: toks, cur: p.Context}
+	if ps.Books == nil {
+		ps.Books = CanonicalBooks
+	}
+	if ps.peek().kind == tokEOF {
+		return nil, ps.errorf(ps.peek(), "empty reference")
+	}
+	for {
+		if err := ps.group(); err != nil {
+			return nil, err
+		}
+		switch tok := ps.next(); {
+		case tok.kind == tokEOF:
+			return ps.out, nil
+		case tok.kind == tokSemi || tok.kind == tokPlus || isAnd(tok):
+			// A new group starts at chapter level
+			ps.cur.Chapter = 0
+		default:
+			return nil, ps.errorf(tok, "unexpected %q", tok.text)
+		}
+	}
+}
+
+func (ps *parser) peek() token { return ps.toks[ps.i] }
+
+// pos is the input offset just past the last token read.
+func (ps *parser) pos() int {
+	if ps.i == 0 {
+		return 0
+	}
+	tok := ps.toks[ps.i-1]
+	return tok.pos + len(tok.text)
+}
+
+func (ps *parser) next() token {
+	tok := ps.toks[ps.i]
+	if tok.kind != tokEOF {
+		ps.i++
+	}
+	return tok
+}
+
+func (ps *parser) errorf(tok token, format string, args ...any) error {
+	return &ParseError{Input: ps.input, Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// group reads an optional book followed by comma-separated chapters, verses and ranges.
+func (ps *parser) group() error {
+	start := ps.peek()
+	book, err := ps.book()
+	if err != nil {
+		return err
+	}
+	if book == "" {
+		if book, err = ps.correct(start); err != nil {
+			return err
+		}
+	}
+	if book != "" {
+		ps.cur = Ref{Book: book}
+		if ps.peek().kind == tokDash {
+			return ps.rangeFrom(start, Ref{Book: book})
+		}
+		if ps.atGroupEnd() {
+			ps.out = append(ps.out, Range{Start: ps.cur, End: ps.cur})
+			return nil
+		}
+	} else if ps.cur.Book == "" {
+		return ps.errorf(start, "no book given")
+	}
+
+	for {
+		if err := ps.item(); err != nil {
+			return err
+		}
+		if ps.peek().kind != tokComma {
+			return nil
+		}
+		ps.next()
+	}
+}
+
+// item reads one chapter, verse or range starting at one. Whether a bare number is a chapter or
+// a verse depends on whether a chapter is current.
+func (ps *parser) item() error {
+	first := ps.peek()
+	n, err := ps.number("chapter or verse")
+	if err != nil {
+		return err
+	}
+
+	start := Ref{Book: ps.cur.Book}
+	switch {
+	case ps.peek().kind == tokColon:
+		ps.next()
+		v, err := ps.number("verse")
+		if err != nil {
+			return err
+		}
+		start.Chapter, start.Verse = n, v
+	case ps.cur.Chapter != 0:
+		start.Chapter, start.Verse = ps.cur.Chapter, n
+	default:
+		start.Chapter = n
+	}
+	return ps.rangeFrom(first, start)
+}
+
+// rangeFrom reads how the range starting at start ends: "-" and an end, "f" for one more verse
+// or chapter, "ff" for the rest of the chapter (or book), or nothing for a single reference.
+func (ps *parser) rangeFrom(first token, start Ref) error {
+	end := start
+	switch tok := ps.peek(); {
+	case isWord(tok, "ff"):
+		ps.next()
+		end = openEnd(start)
+	case isWord(tok, "f"):
+		ps.next()
+		if start.Verse != 0 {
+			end.Verse++
+		} else {
+			end.Chapter++
+		}
+	case tok.kind == tokDash:
+		ps.next()
+		var err error
+		if end, err = ps.through(&start, tok); err != nil {
+			return err
+		}
+	}
+	if end.Book == start.Book && endsBefore(end, start) {
+		return ps.errorf(first, "invalid range %s: it ends before it starts", strings.TrimSpace(ps.input[first.pos:ps.pos()]))
+	}
+
+	ps.out = append(ps.out, Range{Start: start, End: end})
+	ps.cur = Ref{Book: end.Book}
+	if end.Verse != 0 {
+		ps.cur.Chapter = end.Chapter
+	}
+	return nil
+}
+
+// through reads the end of a range after the dash: a verse ("16-18"), a chapter and verse
+// ("3:16-4:2"), a chapter ("5-7"), another book ("gen 50 - exod 2"), or nothing for an open end.
+// A chapter range that ends at a verse ("3-4:2") starts at verse 1.
+func (ps *parser) through(start *Ref, dash token) (Ref, error) {
+	book, err := ps.book()
+	if err != nil {
+		return Ref{}, err
+	}
+	if book != "" {
+		end := Ref{Book: book}
+		if ps.peek().kind != tokNum {
+			return end, nil
+		}
+		if end.Chapter, err = ps.number("chapter"); err != nil {
+			return end, err
+		}
+		if ps.peek().kind == tokColon {
+			ps.next()
+			end.Verse, err = ps.number("verse")
+		}
+		return end, err
+	}
+
+	if start.Chapter == 0 {
+		return Ref{}, ps.errorf(ps.peek(), "invalid range: expected a book after %q", dash.text)
+	}
+	if ps.peek().kind == tokComma || ps.atGroupEnd() {
+		return openEnd(*start), nil
+	}
+	if ps.peek().kind != tokNum {
+		return Ref{}, ps.errorf(ps.peek(), "invalid range: expected a number or a book after %q", dash.text)
+	}
+
+	n, err := ps.number("chapter or verse")
+	if err != nil {
+		return Ref{}, err
+	}
+	end := Ref{Book: start.Book}
+	switch {
+	case ps.peek().kind == tokColon:
+		ps.next()
+		if end.Verse, err = ps.number("verse"); err != nil {
+			return Ref{}, err
+		}
+		end.Chapter = n
+		if start.Verse == 0 {
+			start.Verse = 1
+		}
+	case start.Verse != 0:
+		end.Chapter, end.Verse = start.Chapter, n
+	default:
+		end.Chapter = n
+	}
+	return end, nil
+}
+
+// openEnd is the end of a range that runs through the rest of the chapter, or of the book.
+func openEnd(start Ref) Ref {
+	if start.Verse != 0 {
+		return Ref{Book: start.Book, Chapter: start.Chapter, Verse: Last}
+	}
+	return Ref{Book: start.Book, Chapter: Last}
+}
+
+// endsBefore reports whether end comes before start in the same book. A zero or Last end
+// chapter or verse runs to the end of the book or chapter.
+func endsBefore(end, start Ref) bool {
+	switch {
+	case end.Chapter == 0 || end.Chapter == Last || end.Chapter > start.Chapter:
+		return false
+	case end.Chapter < start.Chapter:
+		return true
+	}
+	return end.Verse != 0 && end.Verse != Last && end.Verse < start.Verse
+}
+
+// number reads a chapter or verse number, which start at 1.
+func (ps *parser) number(what string) (int, error) {
+	tok := ps.peek()
+	if tok.kind != tokNum {
+		if tok.kind == tokEOF {
+			return 0, ps.errorf(tok, "expected a %s number", what)
+		}
+		return 0, ps.errorf(tok, "expected a %s number, found %q", what, tok.text)
+	}
+	ps.next()
+	n, err := strconv.Atoi(tok.text)
+	if err != nil || n < 1 {
+		return 0, ps.errorf(tok, "%s is not a valid number; chapters and verses start at 1", tok.text)
+	}
+	return n, nil
+}
+
+// book resolves the longest run of tokens that names a book: an optional leading number
+// ("1 John"), words ("Song of Solomon") and an optional trailing number ("Psalm 151"). It returns
+// no book and no error when the tokens name none, and an error when they could name several.
+func (ps *parser) book() (string, error) {
+	j := ps.i
+	if ps.toks[j].kind == tokNum {
+		j++
+	}
+	if ps.toks[j].kind != tokWord {
+		return "", nil
+	}
+	for ps.toks[j].kind == tokWord {
+		j++
+	}
+	if ps.toks[j].kind == tokNum {
+		j++
+	}
+
+	var ambiguous *ParseError
+	for end := j; end > ps.i; end-- {
+		if !hasWord(ps.toks[ps.i:end]) {
+			break
+		}
+		text := joinTokens(ps.toks[ps.i:end])
+		name, candidates := ps.Books(text)
+		if name != "" {
+			ps.i = end
+			return name, nil
+		}
+		if len(candidates) > 1 && ambiguous == nil {
+			ambiguous = &ParseError{Input: ps.input, Pos: ps.toks[ps.i].pos, Msg: fmt.Sprintf("ambiguous book %q", text), Candidates: candidates}
+		}
+	}
+	if ambiguous != nil {
+		return "", ambiguous
+	}
+	return "", nil
+}
+
+// correct reads an unknown book name ("genisis", "1 jhon") as the one book Suggest finds close
+// to it. A name that starts with a word and suggests nothing or several books is an error; one
+// that starts with a number may still be a chapter ("3 ff").
+func (ps *parser) correct(start token) (string, error) {
+	end := ps.i
+	if ps.toks[end].kind == tokNum {
+		end++
+	}
+	words := end
+	for ps.toks[end].kind == tokWord && !isAnd(ps.toks[end]) {
+		end++
+	}
+
+	var candidates []string
+	if ps.Suggest != nil && end > words {
+		candidates = ps.Suggest(joinTokens(ps.toks[ps.i:end]))
+	}
+	switch {
+	case len(candidates) == 1:
+		ps.i = end
+		return candidates[0], nil
+	case start.kind != tokWord:
+		return "", nil
+	}
+	return "", &ParseError{Input: ps.input, Pos: start.pos, Msg: fmt.Sprintf("unknown book %q", ps.bookText(start)), Candidates: candidates}
+}
+
+// bookText is the run of words starting at tok, for error messages.
+func (ps *parser) bookText(tok token) string {
+	j := ps.i
+	for ps.toks[j].kind == tokWord && !isAnd(ps.toks[j]) {
+		j++
+	}
+	if j == ps.i {
+		return tok.text
+	}
+	return joinTokens(ps.toks[ps.i:j])
+}
+
+func (ps *parser) atGroupEnd() bool {
+	tok := ps.peek()
+	return tok.kind == tokEOF || tok.kind == tokSemi || tok.kind == tokPlus || isAnd(tok)
+}
+
+func isAnd(tok token) bool {
+	return isWord(tok, "and")
+}
+
+func isWord(tok token, word string) bool {
+	return tok.kind == tokWord && strings.EqualFold(tok.text, word)
+}
+
+func hasWord(toks []token) bool {
+	for _, tok := range toks {
+		if tok.kind == tokWord {
+			return true
+		}
+	}
+	return false
+}
+
+// joinTokens rebuilds the book part of the input, dropping abbreviation dots ("Gen.").
+func joinTokens(toks []token) string {
+	parts := make([]string, len(toks))
+	for i, tok := range toks {
+		parts[i] = strings.TrimRight(tok.text, ".")
+	}
+	return strings.Join(parts, " ")
+}
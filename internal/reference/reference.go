@@ -0,0 +1,190 @@
+// Package reference parses and formats Bible references such as "John 3:16-18",
+// "1 Cor 13" or "Gen 1:1; 2:4 + Ps 23". It knows nothing about any loaded translation:
+// book names are resolved through a BookResolver, canonical names by default.
+package reference
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+)
+
+// Ref points at a book, a chapter of it, or a verse. A zero Chapter means the whole book,
+// a zero Verse the whole chapter.
+type Ref struct {
+	Book    string
+	Chapter int
+	Verse   int
+}
+
+// Last as an End chapter or verse makes a range run to the end of the book ("gen 48-") or
+// chapter ("ps 119:160-", "rom 8:28ff").
+const Last = -1
+
+// Range runs from Start to End inclusive. A single reference has Start == End. The ends may lie
+// in different chapters ("john 3:16-4:3") or books ("gen 50 - exod 2"); an End without a chapter
+// runs to the end of its book.
+type Range struct {
+	Start Ref
+	End   Ref
+}
+
+// ParseError reports where in the input a reference stopped making sense.
+type ParseError struct {
+	Input      string
+	Pos        int // Byte offset into Input
+	Msg        string
+	Candidates []string // Books an ambiguous name could mean
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+// BookResolver turns the book part of a reference ("1 jn", "Gen") into a book name. A name that
+// fits several books ("ph") resolves to no book but to the candidates.
+type BookResolver func(name string) (book string, candidates []string)
+
+// CanonicalBooks resolves canonical names, aliases and OSIS IDs, and the names and abbreviations
+// of the built-in languages ("Jean", "1 Kor"), to the canonical (English) book name.
+func CanonicalBooks(name string) (string, []string) {
+	info, _ := model.LookupBookName(name, model.BuiltinNames)
+	return info.Name, nil
+}
+
+// Parser parses references against a book resolver and a reading context.
+type Parser struct {
+	Books BookResolver // Defaults to CanonicalBooks
+
+	// Suggest, if set, names the books an unknown name may be a misspelling of. A single
+	// suggestion is read as that book ("genisis" as Genesis); several are given as candidates.
+	Suggest func(name string) []string
+
+	// Context is where a reference without a book applies. With a book, a bare number is a
+	// chapter ("cat 3"); with a chapter too, it is a verse ("cat 16").
+	Context Ref
+}
+
+// Parse reads references with the canonical book names and no context.
+func Parse(s string) ([]Range, error) {
+	return (&Parser{}).Parse(s)
+}
+
+// String formats a reference as "John", "John 3" or "John 3:16".
+func (r Ref) String() string {
+	switch {
+	case r.Chapter == 0:
+		return r.Book
+	case r.Verse == 0:
+		return fmt.Sprintf("%s %d", r.Book, r.Chapter)
+	}
+	return fmt.Sprintf("%s %d:%d", r.Book, r.Chapter, r.Verse)
+}
+
+// String formats a range as "John 3:16-18", "John 3-4", "John 3:16-4:2", "Psalms 119:160-"
+// or "Genesis 50-Exodus 2".
+func (r Range) String() string {
+	return r.Start.String() + r.tail()
+}
+
+// tail is what follows the start of the range: "-18", "-4" or "-4:2".
+func (r Range) tail() string {
+	switch {
+	case r.Start == r.End:
+		return ""
+	case r.Start.Book != r.End.Book:
+		return "-" + r.End.String()
+	case r.End.Chapter == Last, r.End.Verse == Last:
+		return "-"
+	case r.Start.Chapter == r.End.Chapter && r.End.Verse != 0:
+		return "-" + strconv.Itoa(r.End.Verse)
+	case r.End.Verse == 0:
+		return "-" + strconv.Itoa(r.End.Chapter)
+	}
+	return fmt.Sprintf("-%d:%d", r.End.Chapter, r.End.Verse)
+}
+
+// IsVerses reports whether the range is made of whole verses rather than chapters or books.
+func (r Range) IsVerses() bool {
+	return r.Start.Verse != 0
+}
+
+// Format writes ranges so that Parse reads them back unchanged, leaving out repeated book names
+// and chapters: "John 3:16,18; 4:2; Romans 8:28".
+func Format(ranges []Range) string {
+	var sb strings.Builder
+	for i, r := range ranges {
+		if i == 0 {
+			sb.WriteString(r.String())
+			continue
+		}
+		// After a range ending at a verse, a bare number is a verse of the same chapter
+		prev := ranges[i-1]
+		switch {
+		case r.Start.Book != prev.End.Book || r.Start.Chapter == 0 || prev.End.Chapter == 0:
+			sb.WriteString("; " + r.String())
+		case r.IsVerses() && prev.End.Verse != 0 && r.Start.Chapter == prev.End.Chapter:
+			sb.WriteString("," + strconv.Itoa(r.Start.Verse) + r.tail())
+		default:
+			sb.WriteString("; " + strings.TrimPrefix(r.String(), r.Start.Book+" "))
+		}
+	}
+	return sb.String()
+}
+
+// Contains reports whether the verse ref falls inside a range within one book. Split ranges
+// across books first.
+func (r Range) Contains(ref Ref) bool {
+	if ref.Book != r.Start.Book || r.End.Book != r.Start.Book {
+		return false
+	}
+	if r.Start.Chapter == 0 {
+		return true
+	}
+	if endsBefore(ref, r.Start) {
+		return false
+	}
+	switch {
+	case r.End.Chapter == Last:
+		return true
+	case r.End.Verse == 0 || r.End.Verse == Last:
+		return ref.Chapter <= r.End.Chapter
+	}
+	return ref.Chapter < r.End.Chapter || (ref.Chapter == r.End.Chapter && ref.Verse <= r.End.Verse)
+}
+
+// Split breaks a range across books into one range per book, given the book names in reading order.
+func (r Range) Split(books []string) ([]Range, error) {
+	if r.Start.Book == r.End.Book {
+		return []Range{r}, nil
+	}
+	from, to := slices.Index(books, r.Start.Book), slices.Index(books, r.End.Book)
+	switch {
+	case from < 0:
+		return nil, fmt.Errorf("book %s not found", r.Start.Book)
+	case to < 0:
+		return nil, fmt.Errorf("book %s not found", r.End.Book)
+	case to < from:
+		return nil, fmt.Errorf("invalid range %s: it ends before it starts", r)
+	}
+
+	var out []Range
+	for i := from; i <= to; i++ {
+		whole := Ref{Book: books[i]}
+		piece := Range{Start: whole, End: whole}
+		if i == from && r.Start.Chapter != 0 {
+			piece = Range{Start: r.Start, End: Ref{Book: books[i], Chapter: Last}}
+		}
+		if i == to && r.End.Chapter != 0 {
+			piece = Range{Start: Ref{Book: books[i], Chapter: 1}, End: r.End}
+			if r.End.Verse != 0 {
+				piece.Start.Verse = 1
+			}
+		}
+		out = append(out, piece)
+	}
+	return out, nil
+}
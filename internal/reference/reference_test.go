@@ -0,0 +1,275 @@
+package reference
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func verses(book string, ch, from, to int) Range {
+	return Range{Ref{book, ch, from}, Ref{book, ch, to}}
+}
+
+func chapters(book string, from, to int) Range {
+	return Range{Ref{book, from, 0}, Ref{book, to, 0}}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Range
+	}{
+		{"John 3:16", []Range{verses("John", 3, 16, 16)}},
+		{"jhn 3:16", nil}, // Not a name or abbreviation of any built-in language
+		{"jn 3:16", []Range{verses("John", 3, 16, 16)}},
+		{"Jean 3:16", []Range{verses("John", 3, 16, 16)}},
+		{"1 Kor 13:4", []Range{verses("1 Corinthians", 13, 4, 4)}},
+		{"Genese 1:1", []Range{verses("Genesis", 1, 1, 1)}},
+		{`"john 3:16-18"`, []Range{verses("John", 3, 16, 18)}}, // Quoted on a command line
+		{"john3:16-18", []Range{verses("John", 3, 16, 18)}},
+		{"1 Cor 13", []Range{chapters("1 Corinthians", 13, 13)}},
+		{"1john 1:9", []Range{verses("1 John", 1, 9, 9)}},
+		{"Gen. 1.1", []Range{verses("Genesis", 1, 1, 1)}},
+		{"Romans", []Range{{Ref{Book: "Romans"}, Ref{Book: "Romans"}}}},
+		{"Matthew 5-7", []Range{chapters("Matthew", 5, 7)}},
+		{"John 3:16,18-20", []Range{verses("John", 3, 16, 16), verses("John", 3, 18, 20)}},
+		{"John 3:16, 4:2", []Range{verses("John", 3, 16, 16), verses("John", 4, 2, 2)}},
+		{"Psalm 23, 24", []Range{chapters("Psalms", 23, 23), chapters("Psalms", 24, 24)}},
+		{"Gen 1:1; 2:4", []Range{verses("Genesis", 1, 1, 1), verses("Genesis", 2, 4, 4)}},
+		{"Gen 1:1 + John 1:1", []Range{verses("Genesis", 1, 1, 1), verses("John", 1, 1, 1)}},
+		{"Gen 1:1 and Exodus 3", []Range{verses("Genesis", 1, 1, 1), chapters("Exodus", 3, 3)}},
+		{"1 John and 2 John", []Range{{Ref{Book: "1 John"}, Ref{Book: "1 John"}}, {Ref{Book: "2 John"}, Ref{Book: "2 John"}}}},
+		{"Bel and the Dragon 1:3", []Range{verses("Bel and the Dragon", 1, 3, 3)}},
+		{"Psalm 151 1:1", []Range{verses("Psalm 151", 1, 1, 1)}},
+		{"Psalms 151", []Range{chapters("Psalms", 151, 151)}},
+		{"Song of Solomon 2:1", []Range{verses("Song of Solomon", 2, 1, 1)}},
+		{"Mark 1:1–3", []Range{verses("Mark", 1, 1, 3)}},
+		{"John 3:16-4:3", []Range{{Ref{"John", 3, 16}, Ref{"John", 4, 3}}}},
+		{"Matt 5:1-7:29", []Range{{Ref{"Matthew", 5, 1}, Ref{"Matthew", 7, 29}}}},
+		{"John 3-4:2", []Range{{Ref{"John", 3, 1}, Ref{"John", 4, 2}}}},
+		{"Ps 119:160-", []Range{{Ref{"Psalms", 119, 160}, Ref{"Psalms", 119, Last}}}},
+		{"Rom 8:28ff", []Range{{Ref{"Romans", 8, 28}, Ref{"Romans", 8, Last}}}},
+		{"Rom 8:28f", []Range{verses("Romans", 8, 28, 29)}},
+		{"Gen 48-", []Range{{Ref{"Genesis", 48, 0}, Ref{"Genesis", Last, 0}}}},
+		{"John 3:16-4:2, 5", []Range{{Ref{"John", 3, 16}, Ref{"John", 4, 2}}, verses("John", 4, 5, 5)}},
+		{"Gen 50 - Exod 2", []Range{{Ref{"Genesis", 50, 0}, Ref{"Exodus", 2, 0}}}},
+		{"Gen 50:26-Exod 1:5", []Range{{Ref{"Genesis", 50, 26}, Ref{"Exodus", 1, 5}}}},
+		{"1 John-3 John", []Range{{Ref{Book: "1 John"}, Ref{Book: "3 John"}}}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("Parse(%q) should fail, got %v", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	tests := []struct {
+		ctx  Ref
+		in   string
+		want []Range
+	}{
+		{Ref{Book: "John"}, "3", []Range{chapters("John", 3, 3)}},
+		{Ref{Book: "John"}, "3:16", []Range{verses("John", 3, 16, 16)}},
+		{Ref{Book: "John", Chapter: 3}, "16", []Range{verses("John", 3, 16, 16)}},
+		{Ref{Book: "John", Chapter: 3}, "16-18,20", []Range{verses("John", 3, 16, 18), verses("John", 3, 20, 20)}},
+		{Ref{Book: "John", Chapter: 3}, "16; 4", []Range{verses("John", 3, 16, 16), chapters("John", 4, 4)}},
+		{Ref{Book: "John", Chapter: 3}, "Romans 8", []Range{chapters("Romans", 8, 8)}},
+	}
+	for _, tt := range tests {
+		p := &Parser{Context: tt.ctx}
+		got, err := p.Parse(tt.in)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) in %v = %v, %v; want %v", tt.in, tt.ctx, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos int
+		msg string
+	}{
+		{"", 0, "empty reference"},
+		{"3:16", 0, "no book given"},
+		{"Hezekiah 1:1", 0, `unknown book "Hezekiah"`},
+		{"John 3:16-bad", 10, `invalid range: expected a number or a book after "-"`},
+		{"John 3:18-16", 5, "invalid range 3:18-16: it ends before it starts"},
+		{"John 4:2-3:16", 5, "invalid range 4:2-3:16: it ends before it starts"},
+		{"Genesis - 3", 10, `invalid range: expected a book after "-"`},
+		{"John 0", 5, "0 is not a valid number; chapters and verses start at 1"},
+		{"John 3:", 7, "expected a verse number"},
+		{"John 3:16 Romans", 10, `unexpected "Romans"`},
+		{"John 3#16", 6, `unexpected '#'`},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.in)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("Parse(%q) should return a ParseError, got %v", tt.in, err)
+			continue
+		}
+		if perr.Pos != tt.pos || perr.Msg != tt.msg {
+			t.Errorf("Parse(%q) error = %q at %d; want %q at %d", tt.in, perr.Msg, perr.Pos, tt.msg, tt.pos)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"jOHN 3:16", "John 3:16"},
+		{"john 3:16,18-20; 4:2", "John 3:16,18-20; 4:2"},
+		{"gen 1:1 + gen 2 + exod 3:1", "Genesis 1:1; 2; Exodus 3:1"},
+		{"ps 23-24", "Psalms 23-24"},
+		{"rom", "Romans"},
+		{"rom; rom 8", "Romans; Romans 8"},
+		{"john 3:16-4:3,5", "John 3:16-4:3,5"},
+		{"ps 119:160ff, 162", "Psalms 119:160-,162"},
+		{"gen 50 - exodus 2:5; 3", "Genesis 50-Exodus 2:5; 3"},
+	}
+	for _, tt := range tests {
+		ranges, err := Parse(tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.in, err)
+		}
+		if got := Format(ranges); got != tt.want {
+			t.Errorf("Format(Parse(%q)) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{"John 3:16", "gen 1:1-3,5; 2 + ps 23", "1 John and 2 John", "rom 8:28-", "3:16-bad", "john 3:16-4:3ff", "gen 50 - exod 2:5"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		ranges, err := Parse(s)
+		if err != nil {
+			var perr *ParseError
+			if !errors.As(err, &perr) || perr.Pos < 0 || perr.Pos > len(s) {
+				t.Fatalf("Parse(%q) returned a bad error: %v", s, err)
+			}
+			return
+		}
+		formatted := Format(ranges)
+		back, err := Parse(formatted)
+		if err != nil || !reflect.DeepEqual(back, ranges) {
+			t.Fatalf("%q -> %q -> %v, %v; want %v", s, formatted, back, err, ranges)
+		}
+	})
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		r    Range
+		ref  Ref
+		want bool
+	}{
+		{verses("John", 3, 16, 18), Ref{"John", 3, 17}, true},
+		{verses("John", 3, 16, 18), Ref{"John", 3, 19}, false},
+		{verses("John", 3, 16, 18), Ref{"Romans", 3, 17}, false},
+		{chapters("John", 3, 4), Ref{"John", 4, 54}, true},
+		{chapters("John", 3, 4), Ref{"John", 5, 1}, false},
+		{Range{Ref{Book: "John"}, Ref{Book: "John"}}, Ref{"John", 21, 25}, true},
+		{Range{Ref{"John", 3, 16}, Ref{"John", 4, 3}}, Ref{"John", 3, 36}, true},
+		{Range{Ref{"John", 3, 16}, Ref{"John", 4, 3}}, Ref{"John", 4, 4}, false},
+		{Range{Ref{"Psalms", 119, 160}, Ref{"Psalms", 119, Last}}, Ref{"Psalms", 119, 176}, true},
+		{Range{Ref{"Psalms", 119, 160}, Ref{"Psalms", 119, Last}}, Ref{"Psalms", 120, 1}, false},
+		{Range{Ref{"Genesis", 48, 0}, Ref{"Genesis", Last, 0}}, Ref{"Genesis", 50, 26}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Contains(tt.ref); got != tt.want {
+			t.Errorf("%v.Contains(%v) = %v; want %v", tt.r, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	books := []string{"Genesis", "Exodus", "Leviticus", "Numbers"}
+	r := Range{Ref{"Genesis", 50, 26}, Ref{"Numbers", 2, 0}}
+	want := []Range{
+		{Ref{"Genesis", 50, 26}, Ref{"Genesis", Last, 0}},
+		{Ref{Book: "Exodus"}, Ref{Book: "Exodus"}},
+		{Ref{Book: "Leviticus"}, Ref{Book: "Leviticus"}},
+		chapters("Numbers", 1, 2),
+	}
+	if got, err := r.Split(books); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Split = %v, %v; want %v", got, err, want)
+	}
+
+	backwards := Range{Ref{Book: "Exodus"}, Ref{Book: "Genesis"}}
+	if _, err := backwards.Split(books); err == nil {
+		t.Error("A range ending in an earlier book should not split")
+	}
+}
+
+func TestParseAmbiguousBook(t *testing.T) {
+	books := func(name string) (string, []string) {
+		switch strings.ToLower(name) {
+		case "john":
+			return "John", nil
+		case "phil":
+			return "Philippians", nil
+		case "ph":
+			return "", []string{"Philippians", "Philemon"}
+		}
+		return "", nil
+	}
+	p := &Parser{Books: books}
+
+	if got, err := p.Parse("phil 4:13"); err != nil || got[0].Start.Book != "Philippians" {
+		t.Errorf("An exact abbreviation should resolve, got %v, %v", got, err)
+	}
+	_, err := p.Parse("john 3:16; ph 1")
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Msg != `ambiguous book "ph"` || perr.Pos != 11 || len(perr.Candidates) != 2 {
+		t.Errorf("Expected an ambiguity error with candidates, got %#v", err)
+	}
+}
+
+func TestParseSuggest(t *testing.T) {
+	p := &Parser{Suggest: func(name string) []string {
+		return map[string][]string{
+			"genisis": {"Genesis"},
+			"1 jhon":  {"1 John"},
+			"jhn":     {"John", "Jonah"},
+		}[strings.ToLower(name)]
+	}}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"genisis 1:1", "Genesis 1:1"},
+		{"john 3:16; 1 jhon 1:1", "John 3:16; 1 John 1:1"},
+		{"john 3 and 5 ff", "John 3; 5-"},
+	}
+	for _, tt := range tests {
+		got, err := p.Parse(tt.input)
+		if err != nil {
+			t.Errorf("%q: %v", tt.input, err)
+			continue
+		}
+		if s := Format(got); s != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.input, s, tt.want)
+		}
+	}
+
+	_, err := p.Parse("jhn 3:16")
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Msg != `unknown book "jhn"` || len(perr.Candidates) != 2 {
+		t.Errorf("Several suggestions should be listed as candidates, got %#v", err)
+	}
+}
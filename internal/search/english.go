@@ -0,0 +1,72 @@
+package search
+
+import "strings"
+
+// englishIrregular are forms of the King James Version no ending rule can reach
+var englishIrregular = map[string]string{
+	"hath": "hav", "hast": "hav", "had": "hav", "hadst": "hav",
+	"doth": "do", "dost": "do", "didst": "do", "did": "do",
+	"saith": "say", "said": "say", "sayest": "say", "saidst": "say",
+}
+
+// englishKept end in -est or -eth without being a verb or a superlative
+var englishKept = map[string]bool{
+	"priest": true, "forest": true, "harvest": true, "honest": true, "interest": true,
+	"request": true, "manifest": true, "modest": true, "conquest": true, "behest": true,
+	"guest": true, "quest": true, "teeth": true,
+	"nazareth": true, "japheth": true, "elisabeth": true, "ashtoreth": true,
+}
+
+// stemEnglish strips the endings of English inflections, the archaic ones of the King James
+// Version included: "loveth", "lovest", "loved", "loving" and "loves" all give "lov", and
+// "crieth" and "cried" give "cry".
+func stemEnglish(word string) string {
+	if stem, ok := englishIrregular[word]; ok {
+		return stem
+	}
+	word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "’s")
+	if englishKept[word] {
+		return word
+	}
+
+	switch {
+	case endsIn(word, 2, "ieth", "iest", "ied", "ies"):
+		// "crieth", "criest", "cried", "cries"
+		return word[:strings.LastIndex(word, "i")] + "y"
+	case hasStem(word, "eth", 2), hasStem(word, "est", 2):
+		word = word[:len(word)-3]
+	case hasStem(word, "ing", 3), hasStem(word, "ed", 3):
+		word = strings.TrimSuffix(strings.TrimSuffix(word, "ing"), "ed")
+	case endsIn(word, 2, "ches", "shes", "sses", "xes"):
+		// "churches", "bushes", "kisses", "boxes"
+		return word[:len(word)-2]
+	case hasStem(word, "s", 3) && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		word = word[:len(word)-1]
+	}
+
+	// "sinned" gave "sinn" and "blessed" gave "bless": double consonants are undone but for l, s and z
+	if n := len(word); n > 3 && word[n-1] == word[n-2] && !strings.ContainsRune("aeiouylsz", rune(word[n-1])) {
+		word = word[:n-1]
+	}
+	// "love" and "lov" (from "loved") are one stem
+	if len(word) > 3 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "ee") {
+		word = word[:len(word)-1]
+	}
+	return word
+}
+
+// hasStem reports whether word ends with suffix after at least min letters, one of them a vowel.
+func hasStem(word, suffix string, min int) bool {
+	stem, ok := strings.CutSuffix(word, suffix)
+	return ok && len(stem) >= min && strings.ContainsAny(stem, "aeiouy")
+}
+
+// endsIn reports whether word ends with one of the suffixes after at least min letters.
+func endsIn(word string, min int, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && len(stem) >= min {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+// Package search indexes the words of a translation so that searches, counts and completions do
+// not have to read every verse.
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+)
+
+// Doc is one indexed verse.
+type Doc struct {
+	Book    *model.Book
+	Chapter int
+	Verse   *model.Verse
+}
+
+// Posting lists where a word occurs in one verse, as token positions.
+type Posting struct {
+	Doc       int // Index into Index.Docs
+	Positions []int
+}
+
+// Index maps every word of a translation to the verses it occurs in. Docs are in reading order,
+// and so are the postings of each word.
+type Index struct {
+	Docs    []Doc
+	Stemmer Stemmer // For the language of the translation; nil if there is none
+
+	postings map[string][]Posting
+	words    []string // Sorted, for completion
+	lengths  []int    // Words in each verse, for ranking
+	avgLen   float64
+	stemmed  *Index // Built on first use by Stemmed
+}
+
+// Token is a word of a text, folded for lookups, with its byte offsets in the text.
+type Token struct {
+	Word       string
+	Start, End int
+}
+
+// Build indexes every verse of a translation.
+func Build(b *model.Bible) *Index {
+	ix := &Index{Stemmer: StemmerFor(b.Language), postings: make(map[string][]Posting)}
+	total := 0
+	for _, book := range b.Books() {
+		for _, ch := range book.Chapters {
+			for _, v := range ch.Verses {
+				doc := len(ix.Docs)
+				ix.Docs = append(ix.Docs, Doc{book, ch.Number, v})
+				toks := Tokenize(v.Text)
+				ix.lengths = append(ix.lengths, len(toks))
+				total += len(toks)
+				for pos, tok := range toks {
+					list := ix.postings[tok.Word]
+					if n := len(list); n > 0 && list[n-1].Doc == doc {
+						list[n-1].Positions = append(list[n-1].Positions, pos)
+					} else {
+						list = append(list, Posting{Doc: doc, Positions: []int{pos}})
+					}
+					ix.postings[tok.Word] = list
+				}
+			}
+		}
+	}
+	for w := range ix.postings {
+		ix.words = append(ix.words, w)
+	}
+	sort.Strings(ix.words)
+	ix.avgLen = max(float64(total)/float64(max(len(ix.Docs), 1)), 1)
+	return ix
+}
+
+// Tokenize splits text into words: runs of letters and digits, with apostrophes inside a word
+// ("LORD's") kept.
+func Tokenize(text string) []Token {
+	var toks []Token
+	start := -1
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r) || (isApostrophe(r) && start >= 0 && followedByLetter(text[i:]))
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			toks = append(toks, Token{Fold(text[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		toks = append(toks, Token{Fold(text[start:]), start, len(text)})
+	}
+	return toks
+}
+
+// Fold spells a word the way the index stores it: lower case, without accents.
+func Fold(word string) string {
+	return model.NormalizeName(word)
+}
+
+// Postings returns where a word occurs; the word is folded first.
+func (ix *Index) Postings(word string) []Posting {
+	return ix.postings[Fold(word)]
+}
+
+// Count returns how many verses hold a word and how many times it occurs in all.
+func (ix *Index) Count(word string) (verses, occurrences int) {
+	for _, p := range ix.Postings(word) {
+		occurrences += len(p.Positions)
+	}
+	return len(ix.Postings(word)), occurrences
+}
+
+// Containing lists, in reading order, the verses with a word that contains part ("lov" is in
+// "love", "beloved" and "glove").
+func (ix *Index) Containing(part string) []int {
+	part = Fold(part)
+	seen := make(map[int]bool)
+	var docs []int
+	for _, w := range ix.words {
+		if !strings.Contains(w, part) {
+			continue
+		}
+		for _, p := range ix.postings[w] {
+			if !seen[p.Doc] {
+				seen[p.Doc] = true
+				docs = append(docs, p.Doc)
+			}
+		}
+	}
+	sort.Ints(docs)
+	return docs
+}
+
+// Phrase lists, in reading order, the verses where the words occur one right after the other.
+func (ix *Index) Phrase(words ...string) []int {
+	if len(words) == 0 {
+		return nil
+	}
+	var docs []int
+	for _, first := range ix.Postings(words[0]) {
+		for _, pos := range first.Positions {
+			if ix.follows(first.Doc, pos, words[1:]) {
+				docs = append(docs, first.Doc)
+				break
+			}
+		}
+	}
+	return docs
+}
+
+// follows reports whether the words occur in doc from position pos+1 on.
+func (ix *Index) follows(doc, pos int, words []string) bool {
+	for i, w := range words {
+		if !ix.At(w, doc, pos+1+i) {
+			return false
+		}
+	}
+	return true
+}
+
+// At reports whether a word is the token at position pos of a verse.
+func (ix *Index) At(word string, doc, pos int) bool {
+	positions := ix.positions(Fold(word), doc)
+	j := sort.SearchInts(positions, pos)
+	return j < len(positions) && positions[j] == pos
+}
+
+// positions returns where a folded word occurs in a verse, if at all.
+func (ix *Index) positions(word string, doc int) []int {
+	list := ix.postings[word]
+	i := sort.Search(len(list), func(i int) bool { return list[i].Doc >= doc })
+	if i == len(list) || list[i].Doc != doc {
+		return nil
+	}
+	return list[i].Positions
+}
+
+// Complete returns up to limit words starting with prefix, the most frequent first, for
+// autocompletion and concordances.
+func (ix *Index) Complete(prefix string, limit int) []string {
+	prefix = Fold(prefix)
+	start := sort.SearchStrings(ix.words, prefix)
+	var found []string
+	for _, w := range ix.words[start:] {
+		if !strings.HasPrefix(w, prefix) {
+			break
+		}
+		found = append(found, w)
+	}
+	sort.SliceStable(found, func(i, j int) bool { return len(ix.postings[found[i]]) > len(ix.postings[found[j]]) })
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+	return found
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+func followedByLetter(s string) bool {
+	_, size := utf8.DecodeRuneInString(s)
+	r, _ := utf8.DecodeRuneInString(s[size:])
+	return unicode.IsLetter(r)
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+)
+
+func testIndex(t *testing.T) *Index {
+	t.Helper()
+	b, err := model.ParseDatabase([]byte(`{"meta": {"id": "kjv"},
+		"OT": {"Psalms": {"23": {"1": "The LORD is my shepherd; I shall not want."}}},
+		"NT": {"John": {
+			"3": {"16": "For God so loved the world", "17": "For God sent not his Son into the world"},
+			"4": {"10": "he would have given thee living water", "14": "Whosoever drinketh of the water that I shall give him shall never thirst"}},
+		"1 John": {"4": {"8": "He that loveth not knoweth not God; for God is love."}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Build(b)
+}
+
+func TestTokenize(t *testing.T) {
+	var got []string
+	for _, tok := range Tokenize("The LORD's word, Élie—and “the” end'") {
+		got = append(got, tok.Word)
+	}
+	want := []string{"the", "lord's", "word", "elie", "and", "the", "end"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize = %v, want %v", got, want)
+	}
+
+	toks := Tokenize("For God so")
+	if toks[1].Start != 4 || toks[1].End != 7 {
+		t.Errorf("Expected byte offsets 4-7 for God, got %d-%d", toks[1].Start, toks[1].End)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	ix := testIndex(t)
+	ref := func(docs []int) []string {
+		var refs []string
+		for _, d := range docs {
+			refs = append(refs, fmt.Sprintf("%s %d:%d", ix.Docs[d].Book.Name, ix.Docs[d].Chapter, ix.Docs[d].Verse.Number))
+		}
+		return refs
+	}
+
+	if verses, n := ix.Count("god"); verses != 3 || n != 4 {
+		t.Errorf("Count(god) = %d verses, %d occurrences; want 3, 4", verses, n)
+	}
+	if p := ix.Postings("GOD"); len(p) != 3 || !reflect.DeepEqual(p[2].Positions, []int{6, 8}) {
+		t.Errorf("Postings should be folded and carry positions, got %v", p)
+	}
+
+	tests := []struct {
+		name string
+		got  []int
+		want []string
+	}{
+		{"containing lov", ix.Containing("lov"), []string{"John 3:16", "1 John 4:8"}},
+		{"phrase living water", ix.Phrase("living", "water"), []string{"John 4:10"}},
+		{"phrase the world", ix.Phrase("the", "world"), []string{"John 3:16", "John 3:17"}},
+		{"phrase water living", ix.Phrase("water", "living"), nil},
+	}
+	for _, tt := range tests {
+		if got := ref(tt.got); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if got := ix.Complete("s", 3); !reflect.DeepEqual(got, []string{"shall", "sent", "shepherd"}) {
+		t.Errorf("Complete(s) = %v", got)
+	}
+	if got := ix.Complete("zz", 0); got != nil {
+		t.Errorf("Complete(zz) = %v, want nothing", got)
+	}
+}
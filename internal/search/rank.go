@@ -0,0 +1,102 @@
+package search
+
+import (
+	"math"
+	"sort"
+)
+
+// BM25 parameters: how fast repeating a word stops counting, and how much long verses are
+// discounted.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Result is a verse found by Rank with its relevance.
+type Result struct {
+	Doc   int // Index into Index.Docs
+	Score float64
+}
+
+// Rank scores the verses holding any of the words with BM25, and adds a boost for every pair of
+// words of the query found close together in a verse, doubled when they follow each other as in
+// the query ("lost sheep"). Only docs are scored, if given. Results are the best first, ties in
+// reading order.
+func (ix *Index) Rank(words []string, docs []int) []Result {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, w := range words {
+		if w = Fold(w); w != "" && !seen[w] {
+			seen[w] = true
+			terms = append(terms, w)
+		}
+	}
+
+	if docs == nil {
+		found := make(map[int]bool)
+		for _, w := range terms {
+			for _, p := range ix.postings[w] {
+				if !found[p.Doc] {
+					found[p.Doc] = true
+					docs = append(docs, p.Doc)
+				}
+			}
+		}
+		sort.Ints(docs)
+	}
+
+	results := make([]Result, 0, len(docs))
+	for _, d := range docs {
+		score := 0.0
+		for _, w := range terms {
+			tf := float64(len(ix.positions(w, d)))
+			if tf == 0 {
+				continue
+			}
+			norm := 1 - b + b*float64(ix.lengths[d])/ix.avgLen
+			score += ix.idf(w) * tf * (k1 + 1) / (tf + k1*norm)
+		}
+		for i := 1; i < len(terms); i++ {
+			score += ix.proximity(terms[i-1], terms[i], d)
+		}
+		results = append(results, Result{d, score})
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results
+}
+
+// proximity is the boost for two words of a query in one verse: the rarer they are and the
+// closer together, the more it is worth.
+func (ix *Index) proximity(a, c string, doc int) float64 {
+	pa, pc := ix.positions(a, doc), ix.positions(c, doc)
+	if len(pa) == 0 || len(pc) == 0 {
+		return 0
+	}
+	dist := math.MaxInt
+	phrase := false
+	for _, i := range pa {
+		for _, j := range pc {
+			dist = min(dist, abs(j-i))
+			phrase = phrase || j == i+1
+		}
+	}
+	boost := (ix.idf(a) + ix.idf(c)) / 2 / float64(dist)
+	if phrase {
+		boost *= 2
+	}
+	return boost
+}
+
+// idf weighs a word by how few verses hold it.
+func (ix *Index) idf(word string) float64 {
+	n := float64(len(ix.postings[word]))
+	total := float64(len(ix.Docs))
+	return math.Log(1 + (total-n+0.5)/(n+0.5))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
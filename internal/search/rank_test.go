@@ -0,0 +1,47 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	ix := testIndex(t)
+	refs := func(results []Result) []string {
+		var out []string
+		for _, r := range results {
+			d := ix.Docs[r.Doc]
+			out = append(out, fmt.Sprintf("%s %d:%d", d.Book.Name, d.Chapter, d.Verse.Number))
+		}
+		return out
+	}
+
+	tests := []struct {
+		name  string
+		words []string
+		docs  []int
+		want  []string
+	}{
+		// Both words, closer together and in a shorter verse, beat both words further apart;
+		// "God" alone comes last however often it occurs
+		{"Proximity", []string{"God", "world"}, nil, []string{"John 3:16", "John 3:17", "1 John 4:8"}},
+		{"Phrase", []string{"living", "water"}, nil, []string{"John 4:10", "John 4:14"}},
+		{"Only the given verses", []string{"God", "world"}, []int{2, 5}, []string{"John 3:17", "1 John 4:8"}},
+		{"Repeated words count once", []string{"water", "WATER"}, nil, []string{"John 4:10", "John 4:14"}},
+		{"Unknown word", []string{"manna"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refs(ix.Rank(tt.words, tt.docs)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rank(%v) = %v, want %v", tt.words, got, tt.want)
+			}
+		})
+	}
+
+	// Following each other as in the query is worth more than the same words further apart
+	phrase := ix.proximity("living", "water", 3)
+	if apart := ix.proximity("given", "water", 3); phrase <= apart {
+		t.Errorf("Expected the phrase to be boosted more (%f) than words apart (%f)", phrase, apart)
+	}
+}
@@ -0,0 +1,103 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+)
+
+// Stemmer reduces a folded word to a stem shared by its inflections, so that a search for one
+// finds the others ("loveth", "loved" and "love" all give "lov"). Stems need not be words.
+type Stemmer interface {
+	Stem(word string) string
+}
+
+// StemmerFunc adapts a function to the Stemmer interface.
+type StemmerFunc func(word string) string
+
+func (f StemmerFunc) Stem(word string) string { return f(word) }
+
+// stemmers holds a stemmer for each language a translation may be in, by primary language tag
+var stemmers = map[string]Stemmer{
+	"en": StemmerFunc(stemEnglish),
+	"fr": suffixStemmer{min: 3, suffixes: []string{
+		"issements", "issement", "eraient", "assent", "erions", "aient", "erent", "erons", "eront",
+		"ement", "ions", "erez", "ent", "iez", "ait", "ais", "ant", "ees", "ee", "es", "er", "ez",
+		"e", "s", "x"}},
+	"es": suffixStemmer{min: 3, suffixes: []string{
+		"amientos", "imientos", "amiento", "imiento", "aremos", "ieron", "abais", "aron", "aban",
+		"ando", "iendo", "ados", "idos", "adas", "idas", "ado", "ido", "ada", "ida", "aba", "ara",
+		"ar", "er", "ir", "es", "os", "as", "a", "o", "e", "s"}},
+	"de": suffixStemmer{min: 3, suffixes: []string{"ern", "em", "en", "er", "es", "e", "n", "s"}},
+}
+
+// RegisterStemmer adds or replaces the stemmer of a language.
+func RegisterStemmer(lang string, s Stemmer) {
+	stemmers[strings.ToLower(lang)] = s
+}
+
+// StemmerFor returns the stemmer of a language tag ("fr", "fr-CA"; an empty tag is English),
+// or nil if there is none for it.
+func StemmerFor(lang string) Stemmer {
+	for tag, s := range stemmers {
+		if model.SameLanguage(tag, lang) {
+			return s
+		}
+	}
+	return nil
+}
+
+// Stemmed returns the index by stem: the postings of all the words that share a stem are merged
+// under it, so look stems up in it. It is the index itself when its language has no stemmer.
+func (ix *Index) Stemmed() *Index {
+	if ix.Stemmer == nil || ix.stemmed == ix {
+		return ix
+	}
+	if ix.stemmed != nil {
+		return ix.stemmed
+	}
+
+	st := &Index{Docs: ix.Docs, Stemmer: ix.Stemmer, postings: make(map[string][]Posting), lengths: ix.lengths, avgLen: ix.avgLen}
+	st.stemmed = st
+	byStem := make(map[string]map[int][]int)
+	for _, w := range ix.words {
+		stem := ix.Stemmer.Stem(w)
+		if byStem[stem] == nil {
+			byStem[stem] = make(map[int][]int)
+		}
+		for _, p := range ix.postings[w] {
+			byStem[stem][p.Doc] = append(byStem[stem][p.Doc], p.Positions...)
+		}
+	}
+	for stem, docs := range byStem {
+		list := make([]Posting, 0, len(docs))
+		for d, positions := range docs {
+			sort.Ints(positions)
+			list = append(list, Posting{Doc: d, Positions: positions})
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Doc < list[j].Doc })
+		st.postings[stem] = list
+		st.words = append(st.words, stem)
+	}
+	sort.Strings(st.words)
+	ix.stemmed = st
+	return st
+}
+
+// suffixStemmer strips the first of its suffixes a word ends with, longest first, if at least
+// min letters remain. That is enough for the plural and common verb endings of most languages.
+type suffixStemmer struct {
+	min      int
+	suffixes []string
+}
+
+func (s suffixStemmer) Stem(word string) string {
+	for _, suffix := range s.suffixes {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && utf8.RuneCountInString(stem) >= s.min {
+			return stem
+		}
+	}
+	return word
+}
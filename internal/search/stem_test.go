@@ -0,0 +1,95 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStemEnglish(t *testing.T) {
+	tests := []struct {
+		words []string
+		stem  string
+	}{
+		{[]string{"love", "loves", "loved", "loving", "loveth", "lovest"}, "lov"},
+		{[]string{"bless", "blessed", "blesseth", "blessing"}, "bless"},
+		{[]string{"cry", "cries", "cried", "crieth", "crying"}, "cry"},
+		{[]string{"sin", "sins", "sinned", "sinneth"}, "sin"},
+		{[]string{"go", "goeth", "goest"}, "go"},
+		{[]string{"have", "hath", "hast", "having"}, "hav"},
+		{[]string{"say", "saith", "said", "saying"}, "say"},
+		{[]string{"church", "churches"}, "church"},
+		{[]string{"lord", "lord's"}, "lord"},
+		{[]string{"great", "greatest"}, "great"},
+	}
+	for _, tt := range tests {
+		for _, w := range tt.words {
+			if got := stemEnglish(w); got != tt.stem {
+				t.Errorf("stemEnglish(%q) = %q, want %q", w, got, tt.stem)
+			}
+		}
+	}
+
+	// Words that only look inflected are left alone
+	for _, w := range []string{"best", "rest", "priest", "king", "thing", "need", "was", "his", "this", "nazareth"} {
+		if got := stemEnglish(w); got != w && got+"e" != w {
+			t.Errorf("stemEnglish(%q) = %q, want it unchanged", w, got)
+		}
+	}
+}
+
+func TestStemmerFor(t *testing.T) {
+	tests := []struct {
+		lang  string
+		words []string
+		stem  string
+	}{
+		{"", []string{"loveth"}, "lov"},
+		{"fr-CA", []string{"aime", "aimes", "aiment", "aimait", "aimee"}, "aim"},
+		{"es", []string{"salvar", "salvado", "salvados", "salvaba", "salvando"}, "salv"},
+		{"de", []string{"liebe", "lieben", "liebes"}, "lieb"},
+	}
+	for _, tt := range tests {
+		s := StemmerFor(tt.lang)
+		if s == nil {
+			t.Errorf("No stemmer for %q", tt.lang)
+			continue
+		}
+		for _, w := range tt.words {
+			if got := s.Stem(w); got != tt.stem {
+				t.Errorf("%s: Stem(%q) = %q, want %q", tt.lang, w, got, tt.stem)
+			}
+		}
+	}
+
+	if StemmerFor("la") != nil {
+		t.Error("Expected no stemmer for Latin")
+	}
+	RegisterStemmer("la", StemmerFunc(func(w string) string { return w }))
+	defer delete(stemmers, "la")
+	if StemmerFor("la") == nil {
+		t.Error("A registered stemmer should be found")
+	}
+}
+
+func TestStemmedIndex(t *testing.T) {
+	ix := testIndex(t)
+	st := ix.Stemmed()
+	if st.Stemmed() != st {
+		t.Error("Stemming a stemmed index should change nothing")
+	}
+
+	// "loved", "loveth" and "love" are all under "lov", with their positions
+	var docs []int
+	for _, p := range st.Postings("lov") {
+		docs = append(docs, p.Doc)
+	}
+	if !reflect.DeepEqual(docs, []int{1, 5}) {
+		t.Errorf("Postings(lov) = %v, want [1 5]", docs)
+	}
+	if p := st.Postings("lov"); !reflect.DeepEqual(p[1].Positions, []int{2, 10}) {
+		t.Errorf("Expected merged positions [2 10], got %v", p[1].Positions)
+	}
+	if ix.Postings("lov") != nil {
+		t.Error("The index itself should keep whole words")
+	}
+}
@@ -2,32 +2,60 @@ package shell
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/reference"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/search"
 	"github.com/EcclesiaTechStudio/bible-cli/internal/ui"
 )
 
+// defaultContext is how many verses 'cat' shows around a focused verse until 'set context' changes it
+const defaultContext = 2
+
 type Engine struct {
-	DB        *model.Bible
+	DB        *model.Registry
+	Version   string // ID of the active translation
 	Path      []string
 	PrevPath  []string
 	BookIndex map[string]string
+	Ambiguous map[string][]string // Prefixes shared by several books -> their paths
+	Spellings map[string]string   // Full book names -> book, for typo suggestions
+	Context   int                 // Verses shown on each side of a focused verse
 	Bookmarks map[string]string
+
+	indexes map[string]*search.Index // Word index of each translation searched, by ID
 }
 
-func New(db *model.Bible) *Engine {
+// New starts a shell on the first translation of db. A registry without any gets the embedded
+// translation, as main falls back to it when the data given cannot be loaded.
+func New(db *model.Registry) *Engine {
+	if len(db.Bibles) == 0 {
+		b, err := model.LoadDatabase()
+		if err != nil {
+			panic(fmt.Sprintf("no translation loaded, and the embedded one is unreadable: %v", err))
+		}
+		db.Add(b)
+	}
 	e := &Engine{
 		DB:        db,
+		Version:   db.Bibles[0].ID,
 		Path:      []string{},
 		BookIndex: make(map[string]string),
+		Ambiguous: make(map[string][]string),
+		Spellings: make(map[string]string),
 		Bookmarks: make(map[string]string),
+		Context:   defaultContext,
 	}
 	e.buildIndex()
+	e.index() // Ready for the first search
 	e.loadBookmarks()
 	return e
 }
@@ -37,6 +65,16 @@ func (e *Engine) GetPathString() string {
 	return "/" + strings.Join(e.Path, "/")
 }
 
+// GetVersion returns the active translation ID for the prompt
+func (e *Engine) GetVersion() string {
+	return strings.ToUpper(e.Version)
+}
+
+// bible returns the active translation
+func (e *Engine) bible() *model.Bible {
+	return e.DB.Get(e.Version)
+}
+
 // --- COMMAND ROUTING ---
 
 func (e *Engine) RunCommand(input string) {
@@ -58,35 +96,26 @@ func (e *Engine) RunCommand(input string) {
 	case "cd":
 		e.doCD(args)
 	case "cat", "read":
+		// One grammar for every reference: "john 3:16 + rom 8:28", "gen 1:1; 2:4", "3:16,18"
+		e.handleSmartCat(args)
+	case "parallel", "compare":
 		if args == "" {
-			e.doCat("")
-			return
-		}
-
-		// --- MULTI-REF SUPPORT ---
-		// 1. Normalize separators
-		// Allows: "john 3:16 + rom 8:28" OR "john 3:16 and rom 8:28"
-		// We pad with spaces to ensure we don't accidentally split words (though unlikely in Bible books)
-		normalized := strings.ReplaceAll(args, " + ", " |BREAK| ")
-		normalized = strings.ReplaceAll(normalized, " and ", " |BREAK| ")
-		normalized = strings.ReplaceAll(normalized, " AND ", " |BREAK| ") // Case insensitive check
-
-		// 2. Split by our special token
-		segments := strings.Split(normalized, "|BREAK|")
-
-		for _, seg := range segments {
-			cleanSeg := strings.TrimSpace(seg)
-			if cleanSeg == "" {
-				continue
-			}
-			e.handleSmartCat(cleanSeg)
+			fmt.Println("Usage: parallel [--versions kjv,web] [--columns|--stacked] <ref>")
+		} else {
+			e.doParallel(args)
 		}
 	case "grep", "search":
 		if args == "" {
-			fmt.Println("Usage: grep <word>")
+			fmt.Println("Usage: grep [-w] [-E] [-c] [-v] [--count] [-C n] [-A n] [-B n] <word>")
 		} else {
 			e.doGrep(args)
 		}
+	case "words":
+		if args == "" {
+			fmt.Println("Usage: words <prefix>")
+		} else {
+			e.doWords(args)
+		}
 	case "mark":
 		if args == "" {
 			fmt.Println("Usage: mark <name>")
@@ -99,6 +128,30 @@ func (e *Engine) RunCommand(input string) {
 		e.listBookmarks()
 	case "manna", "random":
 		e.doRandom()
+	case "version", "versions":
+		if args == "" {
+			e.listVersions()
+		} else {
+			e.useVersion(args)
+		}
+	case "use":
+		if args == "" {
+			fmt.Println("Usage: use <version>")
+		} else {
+			e.useVersion(args)
+		}
+	case "import":
+		e.doImport(args)
+	case "export":
+		e.doExport(args)
+	case "validate":
+		e.doValidate(args)
+	case "next", "n":
+		e.doStep(1)
+	case "prev", "p":
+		e.doStep(-1)
+	case "set":
+		e.doSet(args)
 	case "help":
 		e.printHelp()
 	case "clear", "cls":
@@ -108,6 +161,9 @@ func (e *Engine) RunCommand(input string) {
 			e.handleSmartCat(input)
 		} else {
 			fmt.Printf("Command '%s' not found.\n", cmd)
+			if found := suggestCommands(cmd); len(found) > 0 {
+				fmt.Printf("%sDid you mean: %s?%s\n", ui.ColorYellow, strings.Join(found, ", "), ui.ColorReset)
+			}
 		}
 	}
 }
@@ -115,44 +171,98 @@ func (e *Engine) RunCommand(input string) {
 // --- INITIALIZATION ---
 
 func (e *Engine) buildIndex() {
-	indexTestament := func(tName string, tMap model.Testament) {
-		e.BookIndex[strings.ToLower(tName)] = "/" + tName
-
-		// Use UI helper for sorting
-		names := ui.GetSortedKeys(tMap)
-
-		for _, name := range names {
-			lower := strings.ToLower(name)
-			fullPath := "/" + tName + "/" + name
-			cleanKey := strings.ReplaceAll(lower, " ", "")
-
-			for i := 1; i <= len(cleanKey); i++ {
-				prefix := cleanKey[:i]
-				if _, exists := e.BookIndex[prefix]; !exists {
-					e.BookIndex[prefix] = fullPath
-				}
+	e.BookIndex = make(map[string]string)
+	e.Ambiguous = make(map[string][]string)
+	e.Spellings = make(map[string]string)
+	paths := make(map[string]string) // OSIS ID -> path, for the name tables
+	prefixes := make(map[string][]string)
+
+	// Prefixes of the books' names and of their localized names; a prefix that several books
+	// share ("ph", "j") finds none of them and is reported as ambiguous instead
+	for _, t := range e.bible().Collections {
+		for _, book := range t.Books {
+			path := "/" + t.ID + "/" + book.Name
+			addPrefixes(prefixes, model.NormalizeName(book.Name), path)
+			if _, ok := paths[book.OSIS]; !ok && book.OSIS != "" {
+				paths[book.OSIS] = path
 			}
-			// Manual overrides
-			if cleanKey == "matthew" {
-				e.BookIndex["mt"] = fullPath
-			}
-			if cleanKey == "mark" {
-				e.BookIndex["mk"] = fullPath
+		}
+	}
+	tables := e.DB.NameTables(e.bible().Language)
+	for _, table := range tables {
+		for _, info := range model.Canon {
+			if names := table.Names(info.OSIS); len(names) > 0 && paths[info.OSIS] != "" {
+				addPrefixes(prefixes, model.NormalizeName(names[0]), paths[info.OSIS])
 			}
-			if cleanKey == "luke" {
-				e.BookIndex["lk"] = fullPath
+		}
+	}
+	for key, found := range prefixes {
+		if len(found) == 1 {
+			e.BookIndex[key] = found[0]
+		} else {
+			e.Ambiguous[key] = found
+		}
+	}
+
+	// Exact names settle ambiguity: collection IDs, the canonical names and OSIS IDs ("Phil"),
+	// then the names and abbreviations of the name tables ("Jean", "Php", "1 Kor"), the
+	// preferred table last so it wins
+	exact := func(name, path string) {
+		key := model.NormalizeName(name)
+		e.BookIndex[key] = path
+		delete(e.Ambiguous, key)
+	}
+	spelling := func(name, path string) {
+		e.Spellings[model.NormalizeName(name)] = path[strings.LastIndex(path, "/")+1:]
+	}
+	for _, t := range e.bible().Collections {
+		exact(t.ID, "/"+t.ID)
+	}
+	for _, info := range model.Canon {
+		if path, ok := paths[info.OSIS]; ok {
+			for _, name := range append([]string{info.OSIS, info.Name}, info.Aliases...) {
+				exact(name, path)
 			}
-			if cleanKey == "john" {
-				e.BookIndex["jn"] = fullPath
+			for _, name := range append([]string{info.Name}, info.Aliases...) {
+				spelling(name, path)
 			}
-			if cleanKey == "philippians" {
-				e.BookIndex["php"] = fullPath
+		}
+	}
+	for i := len(tables) - 1; i >= 0; i-- {
+		for _, info := range model.Canon {
+			if path, ok := paths[info.OSIS]; ok {
+				for _, name := range tables[i].Names(info.OSIS) {
+					exact(name, path)
+				}
+				if names := tables[i].Names(info.OSIS); len(names) > 0 {
+					spelling(names[0], path)
+				}
 			}
 		}
 	}
 
-	indexTestament("OT", e.DB.OT)
-	indexTestament("NT", e.DB.NT)
+	// A book's own name in the translation always finds it
+	for _, t := range e.bible().Collections {
+		for _, book := range t.Books {
+			exact(book.Name, "/"+t.ID+"/"+book.Name)
+			spelling(book.Name, "/"+t.ID+"/"+book.Name)
+		}
+	}
+}
+
+// addPrefixes records path under every prefix of key, keeping the order books were added in
+func addPrefixes(prefixes map[string][]string, key, path string) {
+	for i := 1; i <= len(key); i++ {
+		if i < len(key) && !utf8.RuneStart(key[i]) {
+			continue
+		}
+		if i < len(key) && isDigit(key[i-1]) && isDigit(key[i]) {
+			continue // "psalm1" must not reach "Psalm 151"
+		}
+		if !slices.Contains(prefixes[key[:i]], path) {
+			prefixes[key[:i]] = append(prefixes[key[:i]], path)
+		}
+	}
 }
 
 // --- NAVIGATION ---
@@ -208,44 +318,34 @@ func (e *Engine) doCD(arg string) {
 func (e *Engine) tryLocalStep(target string) bool {
 	switch len(e.Path) {
 	case 0:
-		return e.enterTestament(target)
+		return e.enterCollection(target)
 	case 1:
 		return e.enterBook(target)
 	case 2:
 		return e.enterChapter(target)
+	case 3:
+		return e.enterVerse(target)
 	default:
 		return false
 	}
 }
 
-func (e *Engine) enterTestament(target string) bool {
-	clean := strings.ToLower(target)
-	if clean == "ot" {
-		e.Path = append(e.Path, "OT")
-		return true
-	}
-	if clean == "nt" {
-		e.Path = append(e.Path, "NT")
+func (e *Engine) enterCollection(target string) bool {
+	if c := e.bible().Collection(target); c != nil {
+		e.Path = append(e.Path, c.ID)
 		return true
 	}
 	return false
 }
 
 func (e *Engine) enterBook(target string) bool {
-	cleanTarget := strings.ToLower(strings.ReplaceAll(target, " ", ""))
-
-	var tMap model.Testament
-	if e.Path[0] == "OT" {
-		tMap = e.DB.OT
-	} else {
-		tMap = e.DB.NT
+	t := e.bible().Collection(e.Path[0])
+	if t == nil {
+		return false
 	}
-
-	for k := range tMap {
-		if strings.EqualFold(strings.ReplaceAll(k, " ", ""), cleanTarget) {
-			e.Path = append(e.Path, k)
-			return true
-		}
+	if book := t.Book(target); book != nil {
+		e.Path = append(e.Path, book.Name)
+		return true
 	}
 	return false
 }
@@ -255,180 +355,449 @@ func (e *Engine) enterChapter(target string) bool {
 	if book == nil {
 		return false
 	}
-	if _, ok := book[target]; ok {
-		e.Path = append(e.Path, target)
+	if num, err := strconv.Atoi(target); err == nil && book.Chapter(num) != nil {
+		e.Path = append(e.Path, strconv.Itoa(num))
 		return true
 	}
 	return false
 }
 
-func (e *Engine) tryTeleport(target string) bool {
-	cleanTarget := strings.ToLower(strings.ReplaceAll(target, " ", ""))
-	if targetPath, found := e.BookIndex[cleanTarget]; found {
-		e.Path = strings.Split(strings.TrimPrefix(targetPath, "/"), "/")
-		return true
+func (e *Engine) enterVerse(target string) bool {
+	chapter := e.getChapter(e.getBook(e.Path[0], e.Path[1]), e.Path[2])
+	if chapter == nil {
+		return false
+	}
+	if num, err := strconv.Atoi(target); err == nil {
+		if v := chapter.Verse(num); v != nil {
+			e.Path = append(e.Path, strconv.Itoa(v.Number)) // The first verse of a bridge
+			return true
+		}
 	}
 	return false
 }
 
-// --- READING (CAT) ---
-
-func (e *Engine) handleSmartCat(args string) {
-	if args == "" {
-		e.doCat("")
+// doStep moves to the next (+1) or previous (-1) chapter, or verse at verse depth, crossing
+// books and collections in reading order, and reads it. From a book, 'next' starts at its first
+// chapter and 'prev' goes back to the end of the book before.
+func (e *Engine) doStep(delta int) {
+	if len(e.Path) < 2 {
+		fmt.Printf("%sError: Select a book first.%s\n", ui.ColorRed, ui.ColorReset)
+		return
+	}
+	books := e.bible().Books()
+	bi := slices.IndexFunc(books, func(bk *model.Book) bool { return bk.Collection == e.Path[0] && bk.Name == e.Path[1] })
+	if bi < 0 {
 		return
 	}
 
-	parts := strings.Fields(args)
-
-	// --- GREEDY BOOK MATCHER ---
-	var bestMatchPath string
-	var argsAfterMatch []string
-	var tokensConsumed int
-
-	currentKey := ""
-	for i, part := range parts {
-		currentKey += strings.ToLower(part)
+	ci := -1
+	if delta < 0 {
+		ci = 0
+	}
+	if len(e.Path) >= 3 {
+		num, _ := strconv.Atoi(e.Path[2])
+		if ci = slices.IndexFunc(books[bi].Chapters, func(ch *model.Chapter) bool { return ch.Number == num }); ci < 0 {
+			fmt.Printf("%sChapter %s not found.%s\n", ui.ColorRed, e.Path[2], ui.ColorReset)
+			return
+		}
+	}
 
-		if targetPath, ok := e.BookIndex[currentKey]; ok {
-			bestMatchPath = targetPath
-			tokensConsumed = i + 1
-			if i+1 < len(parts) {
-				argsAfterMatch = parts[i+1:]
+	var ok bool
+	vi := -1
+	if len(e.Path) == 4 {
+		num, _ := strconv.Atoi(e.Path[3])
+		verses := books[bi].Chapters[ci].Verses
+		if vi = slices.IndexFunc(verses, func(v *model.Verse) bool { return v.Number == num }); vi < 0 {
+			fmt.Printf("%sVerse %s not found.%s\n", ui.ColorRed, e.Path[3], ui.ColorReset)
+			return
+		}
+		vi += delta
+		for ok = true; ok && (vi < 0 || vi >= len(books[bi].Chapters[ci].Verses)); {
+			if bi, ci, ok = stepChapter(books, bi, ci, delta); ok && delta < 0 {
+				vi = len(books[bi].Chapters[ci].Verses) - 1
 			} else {
-				argsAfterMatch = []string{}
+				vi = 0
 			}
 		}
+	} else {
+		bi, ci, ok = stepChapter(books, bi, ci, delta)
 	}
-
-	if bestMatchPath != "" {
-		isLocalChapter := false
-		if len(e.Path) == 2 {
-			book := e.getBook(e.Path[0], e.Path[1])
-			if book != nil {
-				if _, ok := book[strings.ToLower(parts[0])]; ok {
-					isLocalChapter = true
-				}
-			}
+	if !ok {
+		edge := "end"
+		if delta < 0 {
+			edge = "beginning"
 		}
+		fmt.Printf("%sThis is the %s of the Bible.%s\n", ui.ColorGray, edge, ui.ColorReset)
+		return
+	}
 
-		if tokensConsumed > 1 || !isLocalChapter {
-			e.Path = strings.Split(strings.TrimPrefix(bestMatchPath, "/"), "/")
-			if len(argsAfterMatch) > 0 {
-				newArgs := strings.Join(argsAfterMatch, " ")
-				e.doCat(newArgs)
-			} else {
-				e.doCat("")
-			}
-			return
+	book, ch := books[bi], books[bi].Chapters[ci]
+	if book.Name != e.Path[1] {
+		fmt.Printf("\n%s%s%s\n", ui.ColorCyan, book.Name, ui.ColorReset)
+	}
+	e.Path = []string{book.Collection, book.Name, strconv.Itoa(ch.Number)}
+	if vi >= 0 {
+		e.Path = append(e.Path, strconv.Itoa(ch.Verses[vi].Number))
+	}
+	e.doCat(nil, nil)
+}
+
+// stepChapter finds the chapter delta away from books[bi].Chapters[ci], moving into the next or
+// previous book past either end. ok is false past the first or last book.
+func stepChapter(books []*model.Book, bi, ci, delta int) (int, int, bool) {
+	for ci += delta; ci < 0 || ci >= len(books[bi].Chapters); {
+		if bi += delta; bi < 0 || bi >= len(books) {
+			return 0, 0, false
 		}
+		ci = 0
+		if delta < 0 {
+			ci = len(books[bi].Chapters) - 1
+		}
+	}
+	return bi, ci, true
+}
+
+// tryTeleport jumps to a book from anywhere. An ambiguous or misspelt name lists the books it
+// could mean and counts as handled, so the path stays where it was.
+func (e *Engine) tryTeleport(target string) bool {
+	if targetPath, found := e.BookIndex[model.NormalizeName(target)]; found {
+		e.Path = strings.Split(strings.TrimPrefix(targetPath, "/"), "/")
+		return true
+	}
+	if _, candidates := e.resolveBook(target); len(candidates) > 0 {
+		fmt.Printf("%s'%s' is ambiguous. Did you mean: %s?%s\n", ui.ColorYellow, target, strings.Join(candidates, ", "), ui.ColorReset)
+		return true
 	}
 
-	e.doCat(args)
+	// A misspelt name goes to the one book it is close to, or lists the few it might be
+	switch books := e.suggestBooks(target); len(books) {
+	case 0:
+		return false
+	case 1:
+		fmt.Printf("%sAssuming '%s' means %s.%s\n", ui.ColorGray, target, books[0], ui.ColorReset)
+		return e.tryTeleport(books[0])
+	default:
+		fmt.Printf("%s'%s' not found. Did you mean: %s?%s\n", ui.ColorYellow, target, strings.Join(books, ", "), ui.ColorReset)
+		return true
+	}
 }
 
-func (e *Engine) doCat(arg string) {
-	if len(e.Path) < 2 {
-		fmt.Printf("%sError: Select a book first.%s\n", ui.ColorRed, ui.ColorReset)
+// --- READING (CAT) ---
+
+func (e *Engine) handleSmartCat(args string) {
+	opts, ref, err := parseOptions(args, catFlags)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", ui.ColorRed, err, ui.ColorReset)
 		return
 	}
-
-	tName, bName := e.Path[0], e.Path[1]
-	book := e.getBook(tName, bName)
-	if book == nil {
+	for name := range parallelFlags {
+		if _, ok := opts[name]; ok {
+			e.doParallel(args) // cat --versions kjv,web
+			return
+		}
+	}
+	w, err := contextWindow(opts)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", ui.ColorRed, err, ui.ColorReset)
 		return
 	}
-
-	if arg == "" && len(e.Path) == 2 {
-		e.renderBook(book)
+	if ref == "" {
+		e.doCat(nil, w)
+		return
+	}
+	ranges, err := e.parseRef(ref)
+	if err != nil {
+		e.printRefError(ref, err)
 		return
 	}
-	if len(e.Path) == 3 && arg == "" {
-		e.renderChapter(e.Path[2], book[e.Path[2]])
+	if ranges, err = e.splitBooks(ranges); err != nil {
+		e.printRefError(ref, err)
 		return
 	}
+	e.follow(ranges)
+	e.doCat(ranges, w)
+}
 
-	var chapNum string
-	var verseArgs string
+// parseRef reads a reference against the active translation's books. Bare numbers refer to the
+// current book ("cat 3") or chapter ("cat 16").
+func (e *Engine) parseRef(input string) ([]reference.Range, error) {
+	p := &reference.Parser{Books: e.resolveBook, Suggest: e.suggestBooks}
+	if len(e.Path) >= 2 {
+		p.Context.Book = e.Path[1]
+	}
+	if len(e.Path) >= 3 {
+		p.Context.Chapter, _ = strconv.Atoi(e.Path[2])
+	}
+	return p.Parse(input)
+}
 
-	if len(e.Path) == 2 {
-		tokens := strings.Fields(strings.ReplaceAll(arg, ":", " "))
-		chapNum = tokens[0]
-		if len(tokens) > 1 {
-			verseArgs = tokens[1]
+// resolveBook finds a book of the active translation by name, prefix or shorthand. A prefix
+// several books share finds none; their names come back as candidates.
+func (e *Engine) resolveBook(name string) (string, []string) {
+	key := model.NormalizeName(name)
+	if path, ok := e.BookIndex[key]; ok {
+		if parts := strings.Split(strings.TrimPrefix(path, "/"), "/"); len(parts) == 2 {
+			return parts[1], nil
 		}
-	} else {
-		chapNum = e.Path[2]
-		verseArgs = arg
+		return "", nil
+	}
+	var candidates []string
+	for _, path := range e.Ambiguous[key] {
+		candidates = append(candidates, path[strings.LastIndex(path, "/")+1:])
 	}
+	return "", candidates
+}
 
-	chapter, ok := book[chapNum]
-	if !ok {
-		fmt.Printf("%sChapter %s not found.%s\n", ui.ColorRed, chapNum, ui.ColorReset)
+// splitBooks breaks ranges across books ("gen 50 - exod 2") into one range per book
+func (e *Engine) splitBooks(ranges []reference.Range) ([]reference.Range, error) {
+	var names []string
+	for _, bk := range e.bible().Books() {
+		names = append(names, bk.Name)
+	}
+	var out []reference.Range
+	for _, r := range ranges {
+		pieces, err := r.Split(names)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, pieces...)
+	}
+	return out, nil
+}
+
+// settle replaces the open end of a range ("ps 119:160-", "gen 48-") with the book's last
+// chapter or the chapter's last verse
+func settle(book *model.Book, r reference.Range) reference.Range {
+	if r.End.Chapter == reference.Last && len(book.Chapters) > 0 {
+		r.End.Chapter = max(book.Chapters[len(book.Chapters)-1].Number, r.Start.Chapter)
+		if r.Start.Verse != 0 {
+			r.End.Verse = reference.Last
+		}
+	}
+	if r.End.Verse == reference.Last {
+		r.End.Verse = r.Start.Verse
+		if ch := book.Chapter(r.End.Chapter); ch != nil && len(ch.Verses) > 0 {
+			r.End.Verse = max(ch.Verses[len(ch.Verses)-1].Number, r.End.Verse)
+		}
+	}
+	return r
+}
+
+// follow moves into the book read last, unless the reference stayed in the current one
+func (e *Engine) follow(ranges []reference.Range) {
+	name := ranges[len(ranges)-1].End.Book
+	if len(e.Path) >= 2 && e.Path[1] == name {
 		return
 	}
+	if bk := e.bible().Book(name); bk != nil {
+		e.Path = []string{bk.Collection, bk.Name}
+	}
+}
+
+// printRefError shows where a reference stopped making sense
+func (e *Engine) printRefError(input string, err error) {
+	msg := err.Error()
+	fmt.Printf("%s%s%s\n", ui.ColorRed, strings.ToUpper(msg[:1])+msg[1:], ui.ColorReset)
+	var perr *reference.ParseError
+	if errors.As(err, &perr) {
+		fmt.Printf("%s  %s\n  %s^%s\n", ui.ColorGray, input, strings.Repeat(" ", utf8.RuneCountInString(input[:perr.Pos])), ui.ColorReset)
+		if len(perr.Candidates) > 0 {
+			fmt.Printf("%sDid you mean: %s?%s\n", ui.ColorYellow, strings.Join(perr.Candidates, ", "), ui.ColorReset)
+		}
+	}
+}
 
-	if verseArgs == "" {
-		e.renderChapter(chapNum, chapter)
+// doCat prints the given ranges, or the current book, chapter or verse when there are none. With a
+// window, verses are shown with the verses around them.
+func (e *Engine) doCat(ranges []reference.Range, w *window) {
+	if len(ranges) == 0 {
+		if len(e.Path) < 2 {
+			fmt.Printf("%sError: Select a book first.%s\n", ui.ColorRed, ui.ColorReset)
+			return
+		}
+		book := e.getBook(e.Path[0], e.Path[1])
+		switch len(e.Path) {
+		case 4:
+			// A focused verse is read in the context set with 'set context'
+			chapter, _ := strconv.Atoi(e.Path[2])
+			verse, _ := strconv.Atoi(e.Path[3])
+			ref := reference.Ref{Book: e.Path[1], Chapter: chapter, Verse: verse}
+			if w == nil {
+				w = &window{e.Context, e.Context}
+			}
+			e.renderWindow(book, reference.Range{Start: ref, End: ref}, *w)
+		case 3:
+			e.renderChapter(e.getChapter(book, e.Path[2]))
+		default:
+			e.renderBook(book)
+		}
 		return
 	}
 
-	fmt.Printf("\n%sReading %s %s:%s%s\n", ui.ColorCyan, bName, chapNum, verseArgs, ui.ColorReset)
+	for i := 0; i < len(ranges); {
+		r := ranges[i]
+		i++
+		book := e.bible().Book(r.Start.Book)
+		if book == nil {
+			fmt.Printf("%sBook %s not found.%s\n", ui.ColorRed, r.Start.Book, ui.ColorReset)
+			continue
+		}
+		if r.Start.Chapter == 0 {
+			e.renderBook(book)
+			continue
+		}
+		r = settle(book, r)
+		if w != nil && r.IsVerses() {
+			e.renderWindow(book, r, *w)
+			continue
+		}
+		if !r.IsVerses() {
+			for c := r.Start.Chapter; c <= r.End.Chapter; c++ {
+				if ch := book.Chapter(c); ch != nil {
+					e.renderChapter(ch)
+				} else {
+					fmt.Printf("%sChapter %d not found.%s\n", ui.ColorRed, c, ui.ColorReset)
+				}
+			}
+			continue
+		}
 
-	segments := strings.Split(verseArgs, ",")
-	for _, rawSeg := range segments {
-		seg := strings.TrimSpace(rawSeg)
-		if seg == "" {
+		chapter := book.Chapter(r.Start.Chapter)
+		if chapter == nil {
+			fmt.Printf("%sChapter %d not found.%s\n", ui.ColorRed, r.Start.Chapter, ui.ColorReset)
+			continue
+		}
+		if r.End.Chapter != r.Start.Chapter {
+			e.renderPassage(book, r)
 			continue
 		}
 
-		if strings.Contains(seg, "-") {
-			rangeParts := strings.Split(seg, "-")
-			start, err1 := strconv.Atoi(rangeParts[0])
-			end, err2 := strconv.Atoi(rangeParts[1])
+		// Verses of the same chapter read under one header
+		group := []reference.Range{r}
+		for i < len(ranges) && ranges[i].IsVerses() && ranges[i].Start.Book == r.Start.Book &&
+			ranges[i].Start.Chapter == r.Start.Chapter && ranges[i].End.Chapter == r.Start.Chapter {
+			group = append(group, settle(book, ranges[i]))
+			i++
+		}
+		e.renderVerses(book, chapter, group)
+	}
+}
 
-			if err1 != nil || err2 != nil {
-				fmt.Printf("%sInvalid range: %s%s\n", ui.ColorRed, seg, ui.ColorReset)
-				continue
+// renderPassage prints verses running across chapters, marking where each new chapter starts
+func (e *Engine) renderPassage(book *model.Book, r reference.Range) {
+	fmt.Printf("\n%sReading %s%s\n", ui.ColorCyan, r, ui.ColorReset)
+	for _, ch := range book.Chapters {
+		if ch.Number < r.Start.Chapter || ch.Number > r.End.Chapter {
+			continue
+		}
+		if ch.Number > r.Start.Chapter {
+			fmt.Println(ui.ColorGray + "── Chapter " + strconv.Itoa(ch.Number) + " ──" + ui.ColorReset)
+		}
+		for _, v := range ch.Verses {
+			if r.Contains(reference.Ref{Book: r.Start.Book, Chapter: ch.Number, Verse: v.Number}) {
+				fmt.Printf("%s%3s: %s%v\n", ui.ColorYellow, v.Label(), ui.ColorReset, v.Text)
 			}
+		}
+	}
+	fmt.Println()
+}
 
-			for i := start; i <= end; i++ {
-				vKey := strconv.Itoa(i)
-				if text, ok := chapter[vKey]; ok {
-					fmt.Printf("%s%3d: %s%v\n", ui.ColorYellow, i, ui.ColorReset, text)
-				} else {
-					fmt.Printf("%s     (End of chapter)%s\n", ui.ColorGray, ui.ColorReset)
-					break
-				}
+func (e *Engine) renderVerses(book *model.Book, chapter *model.Chapter, group []reference.Range) {
+	label := strings.TrimPrefix(reference.Format(group), group[0].Start.Book+" ")
+	fmt.Printf("\n%sReading %s %s%s\n", ui.ColorCyan, book.Name, label, ui.ColorReset)
+
+	for _, r := range group {
+		if r.Start.Verse == r.End.Verse {
+			if v := chapter.Verse(r.Start.Verse); v != nil {
+				fmt.Printf("%s%3s: %s%v\n", ui.ColorYellow, v.Label(), ui.ColorReset, v.Text)
+			} else {
+				fmt.Printf("%sVerse %d not found.%s\n", ui.ColorRed, r.Start.Verse, ui.ColorReset)
 			}
 			continue
 		}
+		for n := r.Start.Verse; n <= r.End.Verse; n++ {
+			v := chapter.Verse(n)
+			if v == nil {
+				fmt.Printf("%s     (End of chapter)%s\n", ui.ColorGray, ui.ColorReset)
+				break
+			}
+			if v.Number == n || n == r.Start.Verse { // A bridge is printed once
+				fmt.Printf("%s%3s: %s%v\n", ui.ColorYellow, v.Label(), ui.ColorReset, v.Text)
+			}
+		}
+	}
+	fmt.Println()
+}
+
+// renderWindow prints the verses of a range with w.before verses before them and w.after after
+// them dimmed, running into the neighbouring chapters when needed
+func (e *Engine) renderWindow(book *model.Book, r reference.Range, w window) {
+	if book == nil {
+		return
+	}
+	verses := bookVerses(book)
+	first := slices.IndexFunc(verses, func(v bookVerse) bool { return r.Contains(v.ref(book)) })
+	if first < 0 {
+		fmt.Printf("%sVerse %d:%d not found.%s\n", ui.ColorRed, r.Start.Chapter, r.Start.Verse, ui.ColorReset)
+		return
+	}
+	last := first
+	for last+1 < len(verses) && r.Contains(verses[last+1].ref(book)) {
+		last++
+	}
 
-		if text, ok := chapter[seg]; ok {
-			i, _ := strconv.Atoi(seg)
-			fmt.Printf("%s%3d: %s%v\n", ui.ColorYellow, i, ui.ColorReset, text)
+	fmt.Printf("\n%sReading %s%s\n", ui.ColorCyan, r, ui.ColorReset)
+	chapter := r.Start.Chapter
+	for i := max(first-w.before, 0); i <= min(last+w.after, len(verses)-1); i++ {
+		v := verses[i]
+		if v.Chapter != chapter {
+			chapter = v.Chapter
+			fmt.Println(ui.ColorGray + "── Chapter " + strconv.Itoa(chapter) + " ──" + ui.ColorReset)
+		}
+		if i < first || i > last {
+			fmt.Printf("%s%3s: %v%s\n", ui.ColorGray, v.Label(), v.Text, ui.ColorReset)
 		} else {
-			fmt.Printf("%sVerse %s not found.%s\n", ui.ColorRed, seg, ui.ColorReset)
+			fmt.Printf("%s%3s: %s%v\n", ui.ColorYellow, v.Label(), ui.ColorReset, v.Text)
 		}
 	}
 	fmt.Println()
 }
 
+// bookVerse is a verse with the number of its chapter
+type bookVerse struct {
+	Chapter int
+	*model.Verse
+}
+
+func (v bookVerse) ref(book *model.Book) reference.Ref {
+	return reference.Ref{Book: book.Name, Chapter: v.Chapter, Verse: v.Number}
+}
+
+// bookVerses lists every verse of a book in order, so context can run across chapters
+func bookVerses(book *model.Book) []bookVerse {
+	var verses []bookVerse
+	for _, ch := range book.Chapters {
+		for _, v := range ch.Verses {
+			verses = append(verses, bookVerse{ch.Number, v})
+		}
+	}
+	return verses
+}
+
 // --- RENDERING ---
 
 func (e *Engine) doLS() {
 	if len(e.Path) == 0 {
 		fmt.Println(ui.ColorGray + "── Bible Root ──" + ui.ColorReset)
-		fmt.Println(ui.ColorBlue + "OT  " + ui.ColorReset + "(Old Testament)")
-		fmt.Println(ui.ColorBlue + "NT  " + ui.ColorReset + "(New Testament)")
+		for _, t := range e.bible().Collections {
+			fmt.Printf("%s%-4s%s(%s)\n", ui.ColorBlue, t.ID, ui.ColorReset, t.Name)
+		}
 		return
 	}
 	if len(e.Path) == 1 {
-		tMap := e.DB.OT
-		if e.Path[0] == "NT" {
-			tMap = e.DB.NT
+		if t := e.bible().Collection(e.Path[0]); t != nil {
+			e.renderCollection(t)
 		}
-		e.renderTestament(tMap)
 		return
 	}
 	if len(e.Path) == 2 {
@@ -436,92 +805,39 @@ func (e *Engine) doLS() {
 		e.renderBook(book)
 		return
 	}
-	if len(e.Path) == 3 {
+	if len(e.Path) >= 3 {
 		book := e.getBook(e.Path[0], e.Path[1])
-		chap := book[e.Path[2]]
-		e.renderChapter(e.Path[2], chap)
+		e.renderChapter(e.getChapter(book, e.Path[2]))
 	}
 }
 
-func (e *Engine) renderTestament(t model.Testament) {
-	keys := ui.GetSortedKeys(t)
+func (e *Engine) renderCollection(t *model.Collection) {
 	fmt.Println(ui.ColorGray + "── Books ──" + ui.ColorReset)
-	for _, k := range keys {
-		fmt.Printf("%sDIR  %s%s\n", ui.ColorBlue, k, ui.ColorReset)
+	for _, b := range t.Books {
+		fmt.Printf("%sDIR  %s%s\n", ui.ColorBlue, b.Name, ui.ColorReset)
 	}
 }
 
-func (e *Engine) renderBook(bk model.Book) {
-	keys := ui.GetSortedKeys(bk)
-	fmt.Println(ui.ColorGray + "── Chapters ──" + ui.ColorReset)
-	for _, k := range keys {
-		fmt.Printf("%sDIR  %s%s\n", ui.ColorBlue, k, ui.ColorReset)
+func (e *Engine) renderBook(bk *model.Book) {
+	if bk == nil {
+		return
 	}
-}
-
-func (e *Engine) renderChapter(cNum string, ch model.Chapter) {
-	keys := ui.GetSortedKeys(ch)
-	fmt.Println(ui.ColorGray + "── Reading " + cNum + " ──" + ui.ColorReset)
-	for _, k := range keys {
-		fmt.Printf("%s%3s: %s%v\n", ui.ColorYellow, k, ui.ColorReset, ch[k])
+	fmt.Println(ui.ColorGray + "── Chapters ──" + ui.ColorReset)
+	for _, ch := range bk.Chapters {
+		fmt.Printf("%sDIR  %d%s\n", ui.ColorBlue, ch.Number, ui.ColorReset)
 	}
 }
 
-// --- SEARCH ---
-
-func (e *Engine) doGrep(query string) {
-	query = strings.ToLower(strings.ReplaceAll(query, "\"", ""))
-	fmt.Printf("%sSearching for '%s'...%s\n", ui.ColorGray, query, ui.ColorReset)
-	count := 0
-
-	searchChapter := func(bName, cName string, ch model.Chapter) {
-		keys := ui.GetSortedKeys(ch)
-		for _, vKey := range keys {
-			text := ch[vKey]
-			if strings.Contains(strings.ToLower(text), query) {
-				count++
-				lowerText := strings.ToLower(text)
-				idx := strings.Index(lowerText, query)
-				highlighted := text[:idx] + ui.ColorRed + text[idx:idx+len(query)] + ui.ColorReset + text[idx+len(query):]
-
-				fmt.Printf("%s[%s %s:%s] %s%s\n", ui.ColorCyan, bName, cName, vKey, ui.ColorReset, highlighted)
-			}
-		}
+func (e *Engine) renderChapter(ch *model.Chapter) {
+	if ch == nil {
+		return
 	}
-
-	// Helper to search a whole testament
-	searchTestament := func(t model.Testament) {
-		for bName, book := range t {
-			for cName, chapter := range book {
-				searchChapter(bName, cName, chapter)
-			}
+	fmt.Println(ui.ColorGray + "── Reading " + strconv.Itoa(ch.Number) + " ──" + ui.ColorReset)
+	for _, v := range ch.Verses {
+		if v.Heading != "" {
+			fmt.Printf("\n%s%s%s\n", ui.ColorBold, v.Heading, ui.ColorReset)
 		}
-	}
-
-	// Context Aware Search
-	if len(e.Path) == 0 {
-		searchTestament(e.DB.OT)
-		searchTestament(e.DB.NT)
-	} else if len(e.Path) == 1 {
-		tMap := e.DB.OT
-		if e.Path[0] == "NT" {
-			tMap = e.DB.NT
-		}
-		searchTestament(tMap)
-	} else if len(e.Path) == 2 {
-		bk := e.getBook(e.Path[0], e.Path[1])
-		for ck, cv := range bk {
-			searchChapter(e.Path[1], ck, cv)
-		}
-	} else if len(e.Path) == 3 {
-		bk := e.getBook(e.Path[0], e.Path[1])
-		searchChapter(e.Path[1], e.Path[2], bk[e.Path[2]])
-	}
-
-	if count == 0 {
-		fmt.Println("No matches.")
-	} else {
-		fmt.Printf("%sFound %d matches.%s\n", ui.ColorGray, count, ui.ColorReset)
+		fmt.Printf("%s%3s: %s%v\n", ui.ColorYellow, v.Label(), ui.ColorReset, v.Text)
 	}
 }
 
@@ -536,13 +852,18 @@ func (e *Engine) saveBookmark(name string) {
 
 func (e *Engine) goToBookmark(name string) {
 	if target, ok := e.Bookmarks[name]; ok {
-		e.saveHistory()
-		cleanTarget := strings.TrimPrefix(target, "/")
-		if cleanTarget == "" {
-			e.Path = []string{}
-		} else {
-			e.Path = strings.Split(cleanTarget, "/")
+		// Like 'cd', only go where the active translation has something
+		var path []string
+		if cleanTarget := strings.TrimPrefix(target, "/"); cleanTarget != "" {
+			path = strings.Split(cleanTarget, "/")
+		}
+		resolved := e.resolvePath(path)
+		if len(resolved) < len(path) {
+			fmt.Printf("%sBookmark '%s' points to %s, which %s does not have.%s\n", ui.ColorRed, name, target, strings.ToUpper(e.Version), ui.ColorReset)
+			return
 		}
+		e.saveHistory()
+		e.Path = resolved
 	} else {
 		fmt.Printf("%sBookmark '%s' not found.%s\n", ui.ColorRed, name, ui.ColorReset)
 	}
@@ -579,21 +900,85 @@ func (e *Engine) loadBookmarks() {
 	}
 }
 
+// --- TRANSLATIONS ---
+
+func (e *Engine) listVersions() {
+	fmt.Println(ui.ColorCyan + "══ Translations ══" + ui.ColorReset)
+	for _, b := range e.DB.Bibles {
+		marker := "  "
+		if strings.EqualFold(b.ID, e.Version) {
+			marker = ui.ColorGreen + "* "
+		}
+		scheme := ""
+		if b.Scheme() != model.KJV {
+			scheme = fmt.Sprintf(" %s[%s numbering]%s", ui.ColorGray, b.Scheme().Name, ui.ColorReset)
+		}
+		fmt.Printf("%s%s%-6s%s %s%s\n", marker, ui.ColorYellow, strings.ToUpper(b.ID), ui.ColorReset, b.Name, scheme)
+	}
+}
+
+func (e *Engine) useVersion(id string) {
+	b := e.DB.Get(id)
+	if b == nil {
+		fmt.Printf("%sTranslation '%s' not loaded. Available: %s%s\n", ui.ColorRed, id, strings.Join(e.DB.IDs(), ", "), ui.ColorReset)
+		return
+	}
+	e.Version = b.ID
+
+	// Book names can differ between translations, so rebuild the index and re-resolve the path
+	e.buildIndex()
+	e.index()
+	e.Path = e.resolvePath(e.Path)
+	fmt.Printf("%sNow reading %s (%s)%s\n", ui.ColorGreen, strings.ToUpper(b.ID), b.Name, ui.ColorReset)
+}
+
+// resolvePath keeps as much of a path as exists in the active translation
+func (e *Engine) resolvePath(path []string) []string {
+	old := e.Path
+	e.Path = []string{}
+	for _, part := range path {
+		if !e.tryLocalStep(part) {
+			break
+		}
+	}
+	resolved := e.Path
+	e.Path = old
+	return resolved
+}
+
 // --- UTILS ---
 
-func (e *Engine) getBook(tName, bName string) model.Book {
-	var tMap model.Testament
-	if tName == "OT" {
-		tMap = e.DB.OT
-	} else {
-		tMap = e.DB.NT
+func (e *Engine) getBook(tName, bName string) *model.Book {
+	t := e.bible().Collection(tName)
+	if t == nil {
+		return nil
 	}
-	for k, v := range tMap {
-		if strings.EqualFold(k, bName) {
-			return v
+	return t.Book(bName)
+}
+
+func (e *Engine) getChapter(book *model.Book, cName string) *model.Chapter {
+	num, err := strconv.Atoi(cName)
+	if book == nil || err != nil {
+		return nil
+	}
+	return book.Chapter(num)
+}
+
+// doSet changes a shell setting: 'set context 3'
+func (e *Engine) doSet(args string) {
+	name, value, _ := strings.Cut(args, " ")
+	switch strings.ToLower(name) {
+	case "context":
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 0 {
+			fmt.Printf("%sError: context is a number of verses (e.g. 'set context 2').%s\n", ui.ColorRed, ui.ColorReset)
+			return
 		}
+		e.Context = n
+		fmt.Printf("%sShowing %d verse(s) around a focused verse.%s\n", ui.ColorGreen, n, ui.ColorReset)
+	default:
+		fmt.Println("Usage: set context <verses>")
 	}
-	return nil
 }
 
 func (e *Engine) saveHistory() {
@@ -602,27 +987,21 @@ func (e *Engine) saveHistory() {
 }
 
 func (e *Engine) doRandom() {
-	testaments := []string{"OT", "NT"}
-	tKey := testaments[rand.Intn(2)]
-	var tMap model.Testament
-	if tKey == "OT" {
-		tMap = e.DB.OT
-	} else {
-		tMap = e.DB.NT
+	books := e.bible().Books()
+	if len(books) == 0 {
+		return
 	}
+	bk := books[rand.Intn(len(books))]
+	if len(bk.Chapters) == 0 {
+		return
+	}
+	ch := bk.Chapters[rand.Intn(len(bk.Chapters))]
+	if len(ch.Verses) == 0 {
+		return
+	}
+	v := ch.Verses[rand.Intn(len(ch.Verses))]
 
-	books := ui.GetSortedKeys(tMap)
-	bKey := books[rand.Intn(len(books))]
-	bk := tMap[bKey]
-
-	chaps := ui.GetSortedKeys(bk)
-	cKey := chaps[rand.Intn(len(chaps))]
-	ch := bk[cKey]
-
-	vs := ui.GetSortedKeys(ch)
-	vKey := vs[rand.Intn(len(vs))]
-
-	fmt.Printf("\n%s[Random] %s %s:%s%s\n%s%s%s\n\n", ui.ColorCyan, bKey, cKey, vKey, ui.ColorReset, ui.ColorBold, ch[vKey], ui.ColorReset)
+	fmt.Printf("\n%s[Random] %s %d:%d%s\n%s%s%s\n\n", ui.ColorCyan, bk.Name, ch.Number, v.Number, ui.ColorReset, ui.ColorBold, v.Text, ui.ColorReset)
 }
 
 func (e *Engine) printHelp() {
@@ -631,23 +1010,38 @@ func (e *Engine) printHelp() {
 	fmt.Println(ui.ColorBlue + "\n[ NAVIGATION ]" + ui.ColorReset)
 	fmt.Printf("  %scd <book>%s        Teleport (e.g. 'cd rom', 'cd 1 cor')\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Printf("  %scd <chapter>%s     Enter chapter (e.g. 'cd 1')\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %scd <verse>%s       Focus a verse inside a chapter (e.g. 'cd 16')\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Printf("  %scd ..%s            Go back one level\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Printf("  %scd -%s             Jump to previous location (Undo)\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Println(ui.ColorBlue + "\n[ READING ]" + ui.ColorReset)
-	fmt.Printf("  %scat <ref>%s        Read (e.g. 'cat 3:16', '3:16-18')\n", ui.ColorGreen, ui.ColorReset)
-	fmt.Printf("  %scat <book...>%s    Quick read (e.g. 'cat john 3:16')\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %scat <ref>%s        Read (e.g. 'cat 3:16', '3:16-18', '3:16-4:3', '8:28ff')\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %scat <book...>%s    Quick read (e.g. 'cat john 3:16', 'cat -C 2 john 3:16' with context)\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %snext / prev%s      Read the next or previous chapter, or verse when one is focused (n / p)\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %sparallel <ref>%s   Compare translations (e.g. 'parallel --versions kjv,web jn 3:16')\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Println(ui.ColorBlue + "\n[ MEMORY ]" + ui.ColorReset)
 	fmt.Printf("  %smark <name>%s      Save current spot\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Printf("  %sgoto <name>%s      Jump to saved spot\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Printf("  %smarks%s            List all bookmarks\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Println(ui.ColorBlue + "\n[ TOOLS ]" + ui.ColorReset)
-	fmt.Printf("  %sgrep <word>%s      Search contextually\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %sgrep <word>%s      Search contextually (-w words, -E regex, -c case, -v invert, -s word forms, -C 2 context, --rank best first, --limit n, --in gospels)\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %sgrep <query>%s     Combine words (e.g. 'grace AND faith', 'love -hate', '\"living water\"', 'faith NEAR/5 works')\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %swords <prefix>%s   Words starting with a prefix, most frequent first (e.g. 'words righteous')\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Printf("  %smanna%s            Random verse\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %sversion%s          List translations\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %suse <version>%s    Switch translation (e.g. 'use web')\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %simport [fmt] <src>%s Convert USFM/OSIS/Zefania/CSV to JSON (e.g. 'import osis kjv.xml -o kjv.json')\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %sexport%s           Save to json/csv/usfm/osis/markdown/plain (e.g. 'export --format csv --scope \"jn 3\"')\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %svalidate [file]%s  Check a translation for gaps, duplicates and empty verses\n", ui.ColorGreen, ui.ColorReset)
+	fmt.Printf("  %sset context <n>%s  Verses shown around a focused verse\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Printf("  %sclear%s            Clear screen\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Printf("  %sexit%s             Quit\n", ui.ColorGreen, ui.ColorReset)
 	fmt.Println()
 }
 
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
 func isNumeric(s string) bool {
 	if s == "" {
 		return false
@@ -8,28 +8,37 @@ import (
 	"github.com/EcclesiaTechStudio/bible-cli/internal/testutils"
 )
 
-func getMockDB() *model.Bible {
-	return &model.Bible{
-		OT: model.Testament{
-			"Genesis": model.Book{
-				"1": model.Chapter{"1": "In the beginning..."},
-			},
-			"Exodus": model.Book{
-				"1": model.Chapter{"1": "Now these are the names..."},
-			},
+func getMockDB() *model.Registry {
+	kjv := mustParse(`{
+		"meta": { "id": "kjv", "name": "King James Version" },
+		"OT": {
+			"Genesis": { "1": { "1": "In the beginning..." } },
+			"Exodus":  { "1": { "1": "Now these are the names..." } }
 		},
-		NT: model.Testament{
-			"Matthew": model.Book{
-				"1": model.Chapter{"1": "The book of the generation..."},
-			},
-			"John": model.Book{
-				"3": model.Chapter{"16": "For God so loved..."},
-			},
-			"1 John": model.Book{
-				"1": model.Chapter{"1": "That which was from the beginning..."},
-			},
+		"NT": {
+			"Matthew": { "1": { "1": "The book of the generation..." } },
+			"John":    { "3": { "16": "For God so loved..." } },
+			"1 John":  { "1": { "1": "That which was from the beginning..." } }
+		}
+	}`)
+	web := mustParse(`{
+		"meta": { "id": "web", "name": "World English Bible" },
+		"OT": {
+			"Genesis": { "1": { "1": "In the beginning, God created..." } }
 		},
+		"NT": {
+			"John": { "3": { "16": "For God so loved the world..." } }
+		}
+	}`)
+	return model.NewRegistry(kjv, web)
+}
+
+func mustParse(data string) *model.Bible {
+	db, err := model.ParseDatabase([]byte(data))
+	if err != nil {
+		panic(err)
 	}
+	return db
 }
 
 func TestNavigation(t *testing.T) {
@@ -264,3 +273,516 @@ func TestInvalidRange(t *testing.T) {
 		t.Errorf("Expected 'Invalid range' error, got:\n%s", output)
 	}
 }
+
+func TestReferenceGrammar(t *testing.T) {
+	db := getMockDB()
+	engine := New(db)
+	engine.Path = []string{"OT", "Genesis", "1"}
+
+	output := testutils.CaptureOutput(func() {
+		engine.RunCommand("cat 1; john 3:16 and 1 john 1:1")
+	})
+	for _, want := range []string{"Reading Genesis 1:1", "Reading John 3:16", "That which was from the beginning"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in:\n%s", want, output)
+		}
+	}
+	if got := engine.GetPathString(); got != "/NT/1 John" {
+		t.Errorf("Reading should move into the last book, got %s", got)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cat john 3:") })
+	if !strings.Contains(output, "Expected a verse number at position 8") || !strings.Contains(output, "john 3:\n         ^") {
+		t.Errorf("Expected the error position to be shown, got:\n%s", output)
+	}
+}
+
+func TestPassageRanges(t *testing.T) {
+	kjv := mustParse(`{"meta": {"id": "kjv"},
+		"OT": {"Malachi": {"4": {"5": "Behold, I will send you Elijah", "6": "And he shall turn the heart"}}},
+		"NT": {
+			"Matthew": {"1": {"1": "The book of the generation", "2": "Abraham begat Isaac"}},
+			"John": {
+				"3": {"35": "The Father loveth the Son", "36": "He that believeth on the Son"},
+				"4": {"1": "When therefore the Lord knew", "2": "Though Jesus himself baptized not", "3": "He left Judaea"}
+			},
+			"Philippians": {"4": {"1": "Therefore, my brethren", "2": {"text": "I beseech Euodias", "through": 3}, "4": "Rejoice in the Lord alway"}}
+		}}`)
+	engine := New(model.NewRegistry(kjv))
+
+	tests := []struct {
+		command string
+		want    []string
+		not     string
+	}{
+		{"cat john 3:36-4:2", []string{"Reading John 3:36-4:2", "He that believeth", "Chapter 4", "Though Jesus"}, "He left Judaea"},
+		{"cat john 3-4", []string{"The Father loveth", "He left Judaea"}, ""},
+		{"cat john 4:2-", []string{"Reading John 4:2-3", "He left Judaea"}, "When therefore"},
+		{"cat john 4:2ff", []string{"Reading John 4:2-3"}, "When therefore"},
+		{"cat mal 4:6 - matt 1:1", []string{"Reading Malachi 4:6", "turn the heart", "Reading Matthew 1:1", "The book of"}, "Abraham"},
+		{"cat matt 1 - mal 4", []string{"Invalid range"}, "The book of"},
+		{"cat phil 4:3", []string{"2-3: ", "I beseech Euodias"}, "not found"},
+		{"cat phil 4:1-4", []string{"2-3: ", "Rejoice"}, "End of chapter"},
+	}
+	for _, tt := range tests {
+		output := testutils.CaptureOutput(func() { engine.RunCommand(tt.command) })
+		for _, want := range tt.want {
+			if !strings.Contains(output, want) {
+				t.Errorf("%s: expected %q in:\n%s", tt.command, want, output)
+			}
+		}
+		if tt.not != "" && strings.Contains(output, tt.not) {
+			t.Errorf("%s: %q should not be read:\n%s", tt.command, tt.not, output)
+		}
+	}
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("cat phil 4:1-4") })
+	if n := strings.Count(output, "Euodias"); n != 1 {
+		t.Errorf("A verse bridge should be read once, got %d times:\n%s", n, output)
+	}
+}
+
+func TestLS_CanonicalOrder(t *testing.T) {
+	db := getMockDB()
+	engine := New(db)
+	engine.Path = []string{"NT"}
+
+	output := testutils.CaptureOutput(func() {
+		engine.doLS()
+	})
+
+	matt := strings.Index(output, "Matthew")
+	john := strings.Index(output, "DIR  John")
+	firstJohn := strings.Index(output, "1 John")
+	if !(matt < john && john < firstJohn) {
+		t.Errorf("NT listing should follow the canon (Matthew, John, 1 John). Got:\n%s", output)
+	}
+}
+
+func TestVersionSwitching(t *testing.T) {
+	db := getMockDB()
+	engine := New(db)
+	engine.Path = []string{"NT", "John", "3"}
+
+	if engine.GetVersion() != "KJV" {
+		t.Fatalf("Expected KJV to be active by default, got %s", engine.GetVersion())
+	}
+
+	engine.RunCommand("use web")
+	if engine.GetVersion() != "WEB" {
+		t.Errorf("Expected WEB after 'use web', got %s", engine.GetVersion())
+	}
+	if engine.GetPathString() != "/NT/John/3" {
+		t.Errorf("Path should survive a version switch when it exists. Got %s", engine.GetPathString())
+	}
+
+	output := testutils.CaptureOutput(func() {
+		engine.RunCommand("cat 16")
+	})
+	if !strings.Contains(output, "loved the world") {
+		t.Errorf("Expected WEB text, got:\n%s", output)
+	}
+
+	// Exodus only exists in the KJV mock, so the path is trimmed to what WEB has
+	engine.RunCommand("use kjv")
+	engine.Path = []string{"OT", "Exodus"}
+	engine.RunCommand("version web")
+	if engine.GetPathString() != "/OT" {
+		t.Errorf("Expected path trimmed to /OT, got %s", engine.GetPathString())
+	}
+
+	output = testutils.CaptureOutput(func() {
+		engine.RunCommand("use nope")
+	})
+	if !strings.Contains(output, "not loaded") || engine.GetVersion() != "WEB" {
+		t.Errorf("Unknown version should be rejected, got:\n%s", output)
+	}
+}
+
+func TestEmptyRegistry(t *testing.T) {
+	engine := New(model.NewRegistry())
+	if engine.bible() == nil || engine.GetVersion() == "" {
+		t.Fatal("A registry without translations should fall back to the embedded one")
+	}
+	output := testutils.CaptureOutput(func() { engine.RunCommand("ls") })
+	if !strings.Contains(output, "OT") {
+		t.Errorf("Expected the embedded translation's collections, got:\n%s", output)
+	}
+}
+
+func TestParallelReading(t *testing.T) {
+	db := getMockDB()
+	engine := New(db)
+
+	t.Setenv("COLUMNS", "120")
+	columns := testutils.CaptureOutput(func() {
+		engine.RunCommand("parallel john 3:16")
+	})
+	if !strings.Contains(columns, "KJV") || !strings.Contains(columns, "WEB") {
+		t.Errorf("Parallel view should label both translations. Got:\n%s", columns)
+	}
+	if !strings.Contains(columns, "For God so loved...") || !strings.Contains(columns, "For God so loved the world...") {
+		t.Errorf("Parallel view should show both renderings. Got:\n%s", columns)
+	}
+	if engine.GetPathString() != "/NT/John" {
+		t.Errorf("Parallel read should move context like cat. Got %s", engine.GetPathString())
+	}
+
+	// A reference that does not fully resolve leaves the context where it was
+	for _, ref := range []string{"john 3:99", "john 3:16,20"} {
+		engine.Path = []string{"OT", "Genesis"}
+		output := testutils.CaptureOutput(func() {
+			engine.RunCommand("parallel " + ref)
+		})
+		if !strings.Contains(output, "No verses found") || engine.GetPathString() != "/OT/Genesis" {
+			t.Errorf("parallel %s should fail without moving, got %s:\n%s", ref, engine.GetPathString(), output)
+		}
+	}
+
+	stacked := testutils.CaptureOutput(func() {
+		engine.RunCommand("cat --versions web,kjv --stacked gen 1:1")
+	})
+	web := strings.Index(stacked, "In the beginning, God created...")
+	kjv := strings.Index(stacked, "In the beginning...")
+	if web < 0 || kjv < 0 || web > kjv {
+		t.Errorf("Stacked view should follow --versions order. Got:\n%s", stacked)
+	}
+
+	missing := testutils.CaptureOutput(func() {
+		engine.RunCommand("parallel --stacked --versions kjv,web exodus 1:1")
+	})
+	if !strings.Contains(missing, "Now these are the names") || !strings.Contains(missing, "not in this translation") {
+		t.Errorf("Verses missing from one translation should still be listed. Got:\n%s", missing)
+	}
+
+	unknown := testutils.CaptureOutput(func() {
+		engine.RunCommand("parallel --versions kjv,xyz john 3:16")
+	})
+	if !strings.Contains(unknown, "not loaded") {
+		t.Errorf("Unknown translations should be reported. Got:\n%s", unknown)
+	}
+}
+
+func TestParallelPassages(t *testing.T) {
+	kjv := mustParse(`{"meta": {"id": "kjv"}, "NT": {"John": {
+		"3": {"35": "The Father loveth the Son", "36": "He that believeth on the Son"},
+		"4": {"1": "When therefore the Lord knew", "2": "Though Jesus himself baptized not"}}}}`)
+	web := mustParse(`{"meta": {"id": "web"}, "NT": {"John": {
+		"3": {"35": "The Father loves the Son", "36": "One who believes in the Son"},
+		"4": {"1": "Therefore when the Lord knew"}}}}`)
+	engine := New(model.NewRegistry(kjv, web))
+	t.Setenv("COLUMNS", "120")
+
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"parallel --stacked john 3:36-4:2", []string{"He that believeth", "── Chapter 4 ──", "Therefore when", "Though Jesus", "(not in this translation)"}},
+		{"parallel --columns john 3-4", []string{"The Father loves", "── Chapter 4 ──", "(not in this translation)"}},
+		{"cat --stacked --versions web,kjv john 4:1", []string{"Reading John 4:1 (WEB | KJV)"}},
+		{"cat -C 1 --versions kjv,web john 3:36", []string{"-C, -A and -B cannot be combined with parallel reading"}},
+	}
+	for _, tt := range tests {
+		output := testutils.CaptureOutput(func() { engine.RunCommand(tt.command) })
+		for _, want := range tt.want {
+			if !strings.Contains(output, want) {
+				t.Errorf("%s: expected %q in:\n%s", tt.command, want, output)
+			}
+		}
+	}
+}
+
+func TestDeuterocanonicalCollection(t *testing.T) {
+	nab := mustParse(`{
+		"meta": { "id": "nab" },
+		"OT": { "Psalms": { "1": { "1": "Blessed is the man" } } },
+		"DC": {
+			"Tobit":     { "1": { "1": "This book tells the story of Tobit" } },
+			"Psalm 151": { "1": { "1": "I was small among my brothers" } }
+		},
+		"NT": { "John": { "3": { "16": "For God so loved the world" } } }
+	}`)
+	engine := New(model.NewRegistry(nab))
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("ls") })
+	ot, dc, nt := strings.Index(output, "OT"), strings.Index(output, "DC"), strings.Index(output, "NT")
+	if !(ot >= 0 && ot < dc && dc < nt) || !strings.Contains(output, "Deuterocanonical Books") {
+		t.Errorf("Root listing should show OT, DC, NT from the data. Got:\n%s", output)
+	}
+
+	engine.RunCommand("cd dc")
+	engine.RunCommand("cd tobit")
+	if got := engine.GetPathString(); got != "/DC/Tobit" {
+		t.Errorf("Expected /DC/Tobit, got %s", got)
+	}
+	engine.RunCommand("cd /")
+	engine.RunCommand("cd tob")
+	if got := engine.GetPathString(); got != "/DC/Tobit" {
+		t.Errorf("Teleport should find DC books, got %s", got)
+	}
+
+	engine.RunCommand("cd /DC")
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep the") })
+	if !strings.Contains(output, "Tobit") || strings.Contains(output, "John") {
+		t.Errorf("Search inside /DC should stay in the collection. Got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cat psalm 1") })
+	if !strings.Contains(output, "Blessed is the man") {
+		t.Errorf("'psalm 1' should read Psalms 1, not Psalm 151. Got:\n%s", output)
+	}
+}
+
+func TestParallelVersification(t *testing.T) {
+	kjv := mustParse(`{"meta": {"id": "kjv"},
+		"OT": {"Psalms": {"23": {"1": "The LORD is my shepherd"}}, "Malachi": {"4": {"5": "Behold, I will send you Elijah"}}}}`)
+	vg := mustParse(`{"meta": {"id": "vg", "versification": "vulgate"},
+		"OT": {"Psalms": {"22": {"1": "Dominus regit me"}, "23": {"1": "Domini est terra"}}}}`)
+	bhs := mustParse(`{"meta": {"id": "bhs", "versification": "mt"},
+		"OT": {"Malachi": {"3": {"23": "הִנֵּה אָנֹכִי שֹׁלֵחַ לָכֶם אֵת אֵלִיָּה"}}}}`)
+	engine := New(model.NewRegistry(kjv, vg, bhs))
+
+	output := testutils.CaptureOutput(func() {
+		engine.RunCommand("parallel --stacked --versions kjv,vg ps 23:1")
+	})
+	if !strings.Contains(output, "(22:1) Dominus regit me") || strings.Contains(output, "Domini est terra") {
+		t.Errorf("Psalm 23 should resolve to the Vulgate's Psalm 22. Got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() {
+		engine.RunCommand("parallel --stacked --versions kjv,bhs mal 4:5")
+	})
+	if !strings.Contains(output, "(3:23)") {
+		t.Errorf("Malachi 4:5 should resolve to the Hebrew 3:23. Got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("version") })
+	if !strings.Contains(output, "Latin Vulgate numbering") {
+		t.Errorf("Version list should show non-KJV numbering. Got:\n%s", output)
+	}
+}
+
+func TestLocalizedBookNames(t *testing.T) {
+	lsg := mustParse(`{"meta": {"id": "lsg", "language": "fr"},
+		"OT": {"Genèse": {"1": {"1": "Au commencement, Dieu créa les cieux et la terre."}}},
+		"NT": {"Jean": {"3": {"16": "Car Dieu a tant aimé le monde"}}}}`)
+	db := getMockDB()
+	db.Add(lsg)
+	sw, err := model.ParseNameTable([]byte(`{"language": "sw", "books": {"John": ["Yohana", "Yh"]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Names = append(db.Names, sw)
+	engine := New(db)
+
+	tests := []struct {
+		command      string
+		expectedPath string
+	}{
+		{"cd jean", "/NT/John"},   // French name, English translation
+		{"cd mt", "/NT/Matthew"},  // English abbreviation
+		{"cd yohana", "/NT/John"}, // Loaded table
+		{"cd /", "/"},
+		{"use lsg", "/"},
+		{"cd jn", "/NT/Jean"},
+		{"cd john", "/NT/Jean"},
+		{"cd genese", "/OT/Genèse"},
+	}
+	for _, tt := range tests {
+		engine.RunCommand(tt.command)
+		if got := engine.GetPathString(); got != tt.expectedPath {
+			t.Errorf("%s: expected %s, got %s", tt.command, tt.expectedPath, got)
+		}
+	}
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("cat yh 3:16 + gn 1:1") })
+	if !strings.Contains(output, "tant aimé") || !strings.Contains(output, "Au commencement") {
+		t.Errorf("Localized abbreviations should work in references, got:\n%s", output)
+	}
+}
+
+func TestAmbiguousBookNames(t *testing.T) {
+	db := model.NewRegistry(mustParse(`{"meta": {"id": "kjv"}, "NT": {
+		"Philippians": {"4": {"13": "I can do all things through Christ which strengtheneth me."}},
+		"Philemon":    {"1": {"1": "Paul, a prisoner of Jesus Christ..."}}}}`))
+	engine := New(db)
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("cd ph") })
+	if !strings.Contains(output, "Did you mean: Philippians, Philemon?") || engine.GetPathString() != "/" {
+		t.Errorf("An ambiguous prefix should list the books and stay put, got %s:\n%s", engine.GetPathString(), output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cat ph 1") })
+	if !strings.Contains(output, `Ambiguous book "ph" at position 1`) || !strings.Contains(output, "Did you mean: Philippians, Philemon?") {
+		t.Errorf("An ambiguous book in a reference should be reported, got:\n%s", output)
+	}
+
+	tests := []struct {
+		command      string
+		expectedPath string
+	}{
+		{"cd phil", "/NT/Philippians"}, // SBL abbreviation, also a prefix of Philemon
+		{"cd phlm", "/NT/Philemon"},
+		{"cd php", "/NT/Philippians"},
+		{"cd phm", "/NT/Philemon"},
+	}
+	for _, tt := range tests {
+		engine.RunCommand(tt.command)
+		if got := engine.GetPathString(); got != tt.expectedPath {
+			t.Errorf("%s: expected %s, got %s", tt.command, tt.expectedPath, got)
+		}
+	}
+}
+
+func TestVerseNavigation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // 'mark' below saves bookmarks there
+	db := model.NewRegistry(mustParse(`{"meta": {"id": "kjv"}, "NT": {"John": {"3": {
+		"14": "And as Moses lifted up the serpent", "15": "That whosoever believeth in him",
+		"16": "For God so loved the world", "17": "For God sent not his Son", "18": "He that believeth on him",
+		"19": "And this is the condemnation"}}}}`))
+	engine := New(db)
+
+	engine.RunCommand("cd /NT/John/3/16")
+	if got := engine.GetPathString(); got != "/NT/John/3/16" {
+		t.Fatalf("Expected a verse path, got %s", got)
+	}
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("cat") })
+	for _, want := range []string{"Reading John 3:16", "Moses", "For God so loved", "He that believeth"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("cat at a verse should show it with context, missing %q in:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "condemnation") {
+		t.Errorf("Context should stop two verses away, got:\n%s", output)
+	}
+
+	testutils.CaptureOutput(func() { engine.RunCommand("set context 0") })
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cat") })
+	if strings.Contains(output, "Moses") || !strings.Contains(output, "For God so loved") {
+		t.Errorf("With no context only the verse should show, got:\n%s", output)
+	}
+
+	engine.RunCommand("cd ..")
+	engine.RunCommand("cd 19")
+	if got := engine.GetPathString(); got != "/NT/John/3/19" {
+		t.Errorf("Expected cd <verse> inside a chapter to focus it, got %s", got)
+	}
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cd 20") })
+	if !strings.Contains(output, "not found") || engine.GetPathString() != "/NT/John/3/19" {
+		t.Errorf("A missing verse should not be entered, got %s:\n%s", engine.GetPathString(), output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cat 17") })
+	if !strings.Contains(output, "For God sent not") {
+		t.Errorf("A bare number at verse depth should read a verse of the chapter, got:\n%s", output)
+	}
+
+	testutils.CaptureOutput(func() { engine.RunCommand("mark testverse") })
+	engine.RunCommand("cd /")
+	engine.RunCommand("goto testverse")
+	if got := engine.GetPathString(); got != "/NT/John/3/19" {
+		t.Errorf("Bookmarks should keep the verse, got %s", got)
+	}
+}
+
+func TestSequentialReading(t *testing.T) {
+	db := model.NewRegistry(mustParse(`{"meta": {"id": "kjv"},
+		"OT": {"Malachi": {"3": {"1": "Behold, I will send my messenger"}, "4": {"1": "For, behold, the day cometh", "6": "And he shall turn the heart"}}},
+		"NT": {"Matthew": {"1": {"1": "The book of the generation of Jesus Christ", "2": "Abraham begat Isaac"}}}}`))
+	engine := New(db)
+
+	engine.RunCommand("cd /OT/Malachi/3")
+	steps := []struct {
+		command      string
+		expectedPath string
+		expectedText string
+	}{
+		{"next", "/OT/Malachi/4", "the day cometh"},
+		{"n", "/NT/Matthew/1", "The book of the generation"}, // Across books and collections
+		{"n", "/NT/Matthew/1", "end of the Bible"},
+		{"prev", "/OT/Malachi/4", "turn the heart"},
+		{"cd 6", "/OT/Malachi/4/6", ""},
+		{"n", "/NT/Matthew/1/1", "The book of the generation"}, // Verse by verse at verse depth
+		{"n", "/NT/Matthew/1/2", "Abraham begat"},
+		{"p", "/NT/Matthew/1/1", "The book of the generation"},
+		{"p", "/OT/Malachi/4/6", "turn the heart"},
+		{"cd /OT/Malachi", "/OT/Malachi", ""},
+		{"next", "/OT/Malachi/3", "my messenger"}, // From a book, its first chapter
+		{"p", "/OT/Malachi/3", "beginning of the Bible"},
+	}
+	for _, tt := range steps {
+		output := testutils.CaptureOutput(func() { engine.RunCommand(tt.command) })
+		if got := engine.GetPathString(); got != tt.expectedPath {
+			t.Errorf("%s: expected %s, got %s", tt.command, tt.expectedPath, got)
+		}
+		if !strings.Contains(output, tt.expectedText) {
+			t.Errorf("%s: expected %q in:\n%s", tt.command, tt.expectedText, output)
+		}
+	}
+
+	engine.RunCommand("cd /")
+	output := testutils.CaptureOutput(func() { engine.RunCommand("next") })
+	if !strings.Contains(output, "Select a book first") {
+		t.Errorf("next outside a book should ask for one, got:\n%s", output)
+	}
+
+	// A path to a chapter or verse the translation lacks is reported, not stepped from
+	for path, want := range map[string]string{"/OT/Malachi/99/1": "Chapter 99 not found", "/OT/Malachi/99": "Chapter 99 not found", "/OT/Malachi/4/3": "Verse 3 not found"} {
+		engine.Path = strings.Split(strings.TrimPrefix(path, "/"), "/")
+		output = testutils.CaptureOutput(func() { engine.RunCommand("next") })
+		if !strings.Contains(output, want) || engine.GetPathString() != path {
+			t.Errorf("next from %s: expected %q and no move, got %s:\n%s", path, want, engine.GetPathString(), output)
+		}
+	}
+
+	// A bookmark is checked against the translation like a 'cd'
+	engine.RunCommand("cd /OT/Malachi/3")
+	engine.Bookmarks["stale"] = "/OT/Malachi/99/1"
+	output = testutils.CaptureOutput(func() { engine.RunCommand("goto stale") })
+	if !strings.Contains(output, "Bookmark 'stale' points to /OT/Malachi/99/1") || engine.GetPathString() != "/OT/Malachi/3" {
+		t.Errorf("goto a missing chapter should be refused, got %s:\n%s", engine.GetPathString(), output)
+	}
+	engine.Bookmarks["end"] = "/OT/Malachi/4/6"
+	engine.RunCommand("goto end")
+	if engine.GetPathString() != "/OT/Malachi/4/6" {
+		t.Errorf("goto should still work, got %s", engine.GetPathString())
+	}
+}
+
+func TestContextWindow(t *testing.T) {
+	db := model.NewRegistry(mustParse(`{"meta": {"id": "kjv"}, "NT": {"John": {
+		"3": {"34": "For he whom God hath sent", "35": "The Father loveth the Son", "36": "He that believeth on the Son"},
+		"4": {"1": "When therefore the Lord knew", "2": "Though Jesus himself baptized not", "3": "He left Judaea"}}}}`))
+	engine := New(db)
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("cat -C 1 john 3:36") })
+	for _, want := range []string{"Reading John 3:36", "\x1b[90m 35: The Father loveth", "He that believeth", "── Chapter 4 ──", "\x1b[90m  1: When therefore"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("cat -C should show dimmed verses across chapters, missing %q in:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "whom God hath sent") || strings.Contains(output, "Though Jesus") {
+		t.Errorf("cat -C 1 should show one verse on each side, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cat -B 2 -A 0 john 4:1") })
+	if !strings.Contains(output, "The Father loveth") || !strings.Contains(output, "He that believeth") || strings.Contains(output, "Though Jesus") {
+		t.Errorf("cat -B should only show verses before, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cat -C many john 4:1") })
+	if !strings.Contains(output, "option -C needs a number of verses") {
+		t.Errorf("A bad context should be reported, got:\n%s", output)
+	}
+
+	// Hits close together share their context; separate groups are split like grep's
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep -A 1 he") })
+	if strings.Count(output, "[John 4:3]") != 1 || strings.Count(output, "[John 3:36]") != 1 {
+		t.Errorf("Every verse should show once, got:\n%s", output)
+	}
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep -C 1 judaea") })
+	if !strings.Contains(output, "\x1b[90m[John 4:2] Though Jesus") || !strings.Contains(output, "Found 1 matches.") {
+		t.Errorf("grep -C should show dimmed context, got:\n%s", output)
+	}
+}
@@ -0,0 +1,573 @@
+package shell
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/query"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/search"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/ui"
+)
+
+// grepFlags are the options accepted by 'grep': the context window and how the query matches
+var grepFlags = merge(contextFlags, map[string]bool{
+	"-w": false, // Whole words only
+	"-E": false, // Regular expression
+	"-c": false, // Case-sensitive
+	"-v": false, // Verses that do not match
+	"-s": false, // Every form of the words ("love" finds "loved" and "loveth")
+
+	"--count": false, // Only count the matching verses
+	"--rank":  false, // Best matches first
+	"--limit": true,  // Show at most this many matches
+	"--in":    true,  // Search these books or verses instead of the current ones
+})
+
+// rankLimit is how many matches a ranked search shows unless told otherwise
+const rankLimit = 10
+
+// --- SEARCH ---
+
+func (e *Engine) doGrep(args string) {
+	opts, text, err := parseOptions(args, grepFlags)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", ui.ColorRed, err, ui.ColorReset)
+		return
+	}
+	w, err := contextWindow(opts)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", ui.ColorRed, err, ui.ColorReset)
+		return
+	}
+	if w == nil {
+		w = &window{}
+	}
+	if strings.Trim(text, "\" ") == "" {
+		fmt.Println("Usage: grep [-w] [-E] [-c] [-v] [-s] [--count] [--rank] [--limit n] [--in scope] [-C n] [-A n] [-B n] <word>")
+		return
+	}
+	_, rank := opts["--rank"]
+	limit := 0
+	if rank {
+		limit = rankLimit
+	}
+	if value, ok := opts["--limit"]; ok {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+			fmt.Printf("%soption --limit needs a number of matches, got %q%s\n", ui.ColorRed, value, ui.ColorReset)
+			return
+		}
+	}
+	_, regex := opts["-E"]
+	_, invert := opts["-v"]
+	if rank && (regex || invert) {
+		fmt.Printf("%s--rank cannot be combined with -E or -v%s\n", ui.ColorRed, ui.ColorReset)
+		return
+	}
+	var stem search.Stemmer
+	if _, ok := opts["-s"]; ok {
+		if regex {
+			fmt.Printf("%s-s cannot be combined with -E%s\n", ui.ColorRed, ui.ColorReset)
+			return
+		}
+		if stem = e.index().Stemmer; stem == nil {
+			fmt.Printf("%sNo stemmer for the language of %s: matching whole words only.%s\n", ui.ColorYellow, e.Version, ui.ColorReset)
+			stem = search.StemmerFunc(func(w string) string { return w })
+		}
+	}
+	_, caseSensitive := opts["-c"]
+	_, words := opts["-w"]
+	if !regex && (isQuery(text) || stem != nil) && (caseSensitive || words) {
+		fmt.Printf("%s-c and -w cannot be combined with a query or -s: their words always match whole and ignoring case%s\n", ui.ColorRed, ui.ColorReset)
+		return
+	}
+	m, err := newMatcher(text, opts, stem)
+	if err != nil {
+		kind := "pattern"
+		if _, ok := err.(*query.ParseError); ok {
+			kind = "query"
+		}
+		fmt.Printf("%sInvalid %s '%s': %v%s\n", ui.ColorRed, kind, text, err, ui.ColorReset)
+		return
+	}
+	books, keep, err := e.grepScope(opts["--in"])
+	if err != nil {
+		fmt.Printf("%s%v%s\n", ui.ColorRed, err, ui.ColorReset)
+		return
+	}
+	if in := opts["--in"]; in != "" {
+		fmt.Printf("%sSearching for '%s' in %s...%s\n", ui.ColorGray, text, in, ui.ColorReset)
+	} else {
+		fmt.Printf("%sSearching for '%s'...%s\n", ui.ColorGray, text, ui.ColorReset)
+	}
+
+	_, countOnly := opts["--count"]
+	candidates := e.candidates(m, text, opts)
+	if rank {
+		m, results := e.rankedMatches(m, text, opts, books, keep, candidates)
+		if countOnly {
+			printFound(len(results), len(results))
+			return
+		}
+		e.printRanked(m, results, w, limit)
+		return
+	}
+
+	count, total := 0, 0
+	for _, book := range books {
+		verses := bookVerses(book)
+		var hits []int
+		for i, v := range verses {
+			if candidates != nil && !candidates[v.Verse] {
+				continue
+			}
+			if keep(v.Chapter, v.Verse) && m.matches(v.Text) {
+				hits = append(hits, i)
+			}
+		}
+
+		total += len(hits)
+		if countOnly {
+			count += len(hits)
+			continue
+		}
+		if limit > 0 {
+			hits = hits[:min(len(hits), max(limit-count, 0))]
+		}
+
+		// Like grep -C, overlapping context is shown once and separate groups are split by "--"
+		printed := -1
+		for k, i := range hits {
+			from := max(i-w.before, printed+1)
+			if (w.before > 0 || w.after > 0) && count > 0 && (k == 0 || from > printed+1) {
+				fmt.Println(ui.ColorGray + "--" + ui.ColorReset)
+			}
+			for j := from; j < i; j++ {
+				printContext(book, verses[j])
+			}
+
+			fmt.Printf("%s[%s %d:%d] %s%s\n", ui.ColorCyan, book.Name, verses[i].Chapter, verses[i].Number, ui.ColorReset, m.highlight(verses[i].Text))
+			count++
+
+			end := min(i+w.after, len(verses)-1)
+			if k+1 < len(hits) {
+				end = min(end, hits[k+1]-1)
+			}
+			for j := i + 1; j <= end; j++ {
+				printContext(book, verses[j])
+			}
+			printed = max(i, end)
+		}
+	}
+
+	printFound(count, total)
+}
+
+// verseFilter tells whether a verse, in the given chapter, is within the scope of a search
+type verseFilter func(chapter int, v *model.Verse) bool
+
+// grepScope returns the books a search reads and which of their verses it keeps: those of --in
+// ("rom-gal", "gospels", "ps 1-50"), or else the current collection, book or chapter
+func (e *Engine) grepScope(in string) ([]*model.Book, verseFilter, error) {
+	if in != "" {
+		db, err := e.scopeBible(in)
+		if err != nil {
+			return nil, nil, err
+		}
+		var books []*model.Book
+		verses := make(map[*model.Verse]bool)
+		for _, bk := range db.Books() {
+			books = append(books, e.bible().Book(bk.Name))
+			for _, ch := range bk.Chapters {
+				for _, v := range ch.Verses {
+					verses[v] = true
+				}
+			}
+		}
+		return books, func(_ int, v *model.Verse) bool { return verses[v] }, nil
+	}
+
+	// Context Aware Search: the whole Bible, a collection, a book or a chapter
+	var books []*model.Book
+	chapter := 0
+	switch {
+	case len(e.Path) == 0:
+		books = e.bible().Books()
+	case len(e.Path) == 1:
+		if t := e.bible().Collection(e.Path[0]); t != nil {
+			books = t.Books
+		}
+	default:
+		if bk := e.getBook(e.Path[0], e.Path[1]); bk != nil {
+			books = []*model.Book{bk}
+		}
+		if len(e.Path) >= 3 {
+			chapter, _ = strconv.Atoi(e.Path[2])
+		}
+	}
+	return books, func(c int, _ *model.Verse) bool { return chapter == 0 || c == chapter }, nil
+}
+
+// rankedMatches finds the verses of the books matching a search, the most relevant first, and
+// the matcher that highlights them. Without operators, a verse with any of the words is a match
+// ("shepherd lost sheep"), each word found as grep finds it: inside longer words unless -w or
+// -s, in any case unless -c.
+func (e *Engine) rankedMatches(m *matcher, text string, opts map[string]string, books []*model.Book, keep verseFilter, candidates map[*model.Verse]bool) (*matcher, []search.Result) {
+	ix := e.searchIndex(m)
+	inScope := make(map[*model.Book]bool, len(books))
+	for _, bk := range books {
+		inScope[bk] = true
+	}
+	within := func(d search.Doc) bool {
+		return inScope[d.Book] && keep(d.Chapter, d.Verse)
+	}
+
+	var results []search.Result
+	if isQuery(text) {
+		// The query decides which verses match, its words how well
+		docs := []int{}
+		for d, doc := range ix.Docs {
+			if within(doc) && (candidates == nil || candidates[doc.Verse]) && m.matches(doc.Verse.Text) {
+				docs = append(docs, d)
+			}
+		}
+		results = ix.Rank(m.query.Terms(), docs)
+	} else if m.stem == nil {
+		// The words of the verses the search found in them decide the score: "shep" ranks by
+		// "shepherd"
+		var alt []string
+		for _, word := range strings.Fields(strings.ReplaceAll(text, `"`, "")) {
+			alt = append(alt, regexp.QuoteMeta(word))
+		}
+		anyOpts := maps.Clone(opts)
+		anyOpts["-E"] = ""
+		m, _ = newMatcher(strings.Join(alt, "|"), anyOpts, nil) // Quoted words always compile
+		var words []string
+		seen := make(map[string]bool)
+		docs := []int{}
+		for d, doc := range ix.Docs {
+			found := m.find(doc.Verse.Text)
+			if !within(doc) || len(found) == 0 {
+				continue
+			}
+			docs = append(docs, d)
+			for _, tok := range search.Tokenize(doc.Verse.Text) {
+				for _, loc := range found {
+					if loc[0] < tok.End && tok.Start < loc[1] && !seen[tok.Word] {
+						seen[tok.Word] = true
+						words = append(words, tok.Word)
+					}
+				}
+			}
+		}
+		results = ix.Rank(words, docs)
+	} else {
+		var words []string
+		m = &matcher{terms: make(map[string]bool), stem: m.stem}
+		for _, tok := range search.Tokenize(text) {
+			words = append(words, m.word(tok))
+			m.terms[m.word(tok)] = true
+		}
+		for _, r := range ix.Rank(words, nil) {
+			if within(ix.Docs[r.Doc]) {
+				results = append(results, r)
+			}
+		}
+	}
+
+	return m, results
+}
+
+// printRanked prints the best ranked matches, each with its own context
+func (e *Engine) printRanked(m *matcher, results []search.Result, w *window, limit int) {
+	ix := e.searchIndex(m)
+	total := len(results)
+	results = results[:min(len(results), limit)]
+	for k, r := range results {
+		doc := ix.Docs[r.Doc]
+		if (w.before > 0 || w.after > 0) && k > 0 {
+			fmt.Println(ui.ColorGray + "--" + ui.ColorReset)
+		}
+		for d := max(r.Doc-w.before, 0); d < r.Doc; d++ {
+			if ix.Docs[d].Book == doc.Book {
+				printContext(doc.Book, bookVerse{ix.Docs[d].Chapter, ix.Docs[d].Verse})
+			}
+		}
+		fmt.Printf("%s[%s %d:%d] %s%s\n", ui.ColorCyan, doc.Book.Name, doc.Chapter, doc.Verse.Number, ui.ColorReset, m.highlight(doc.Verse.Text))
+		for d := r.Doc + 1; d <= min(r.Doc+w.after, len(ix.Docs)-1); d++ {
+			if ix.Docs[d].Book == doc.Book {
+				printContext(doc.Book, bookVerse{ix.Docs[d].Chapter, ix.Docs[d].Verse})
+			}
+		}
+	}
+	printFound(len(results), total)
+}
+
+// printFound closes a search with how many verses matched, and how many of them were shown
+func printFound(shown, total int) {
+	switch {
+	case total == 0:
+		fmt.Println("No matches.")
+	case shown < total:
+		fmt.Printf("%sShowing %d of %d matches (--limit n for more).%s\n", ui.ColorGray, shown, total, ui.ColorReset)
+	default:
+		fmt.Printf("%sFound %d matches.%s\n", ui.ColorGray, total, ui.ColorReset)
+	}
+}
+
+// index returns the word index of the active translation, built when the translation is loaded
+// or switched to
+func (e *Engine) index() *search.Index {
+	if e.indexes == nil {
+		e.indexes = make(map[string]*search.Index)
+	}
+	ix, ok := e.indexes[e.Version]
+	if !ok {
+		ix = search.Build(e.bible())
+		e.indexes[e.Version] = ix
+	}
+	return ix
+}
+
+// wordsLimit is how many words 'words' lists
+const wordsLimit = 20
+
+// doWords lists the words of the active translation that start with a prefix, the most frequent
+// first, with how many verses hold each: a small concordance to find the spelling to search for.
+func (e *Engine) doWords(prefix string) {
+	prefix = unquote(strings.TrimSpace(prefix))
+	found := e.index().Complete(prefix, wordsLimit+1)
+	if len(found) == 0 {
+		fmt.Printf("No words starting with '%s' in %s.\n", prefix, strings.ToUpper(e.Version))
+		return
+	}
+	shown := found[:min(len(found), wordsLimit)]
+	width := 0
+	for _, w := range shown {
+		width = max(width, utf8.RuneCountInString(w))
+	}
+	for _, w := range shown {
+		verses, occurrences := e.index().Count(w)
+		fmt.Printf("  %s%s%s %5d verses %6d times\n", ui.ColorGreen, ui.Pad(w, width), ui.ColorReset, verses, occurrences)
+	}
+	if len(found) > wordsLimit {
+		fmt.Printf("%sShowing the %d most frequent; type more letters to narrow them down.%s\n", ui.ColorGray, wordsLimit, ui.ColorReset)
+	}
+}
+
+// searchIndex is the index a matcher looks words up in: by stem when it stems them
+func (e *Engine) searchIndex(m *matcher) *search.Index {
+	if m.stem != nil {
+		return e.index().Stemmed()
+	}
+	return e.index()
+}
+
+// candidates narrows a search to the verses holding every word of the text, or part of it, or
+// one of the anchor words of a query, so only those are matched; nil when the index cannot tell
+// (regular expressions, inverted searches, "NOT war")
+func (e *Engine) candidates(m *matcher, text string, opts map[string]string) map[*model.Verse]bool {
+	_, regex := opts["-E"]
+	toks := search.Tokenize(text)
+	if regex || m.invert || len(toks) == 0 {
+		return nil
+	}
+
+	ix := e.searchIndex(m)
+	var docs []int
+	if m.query != nil {
+		anchors := m.query.Anchors()
+		if anchors == nil {
+			return nil
+		}
+		for _, w := range anchors {
+			for _, p := range ix.Postings(w) {
+				docs = append(docs, p.Doc)
+			}
+		}
+	} else {
+		for i, tok := range toks {
+			found := ix.Containing(tok.Word)
+			if i > 0 {
+				found = intersect(docs, found)
+			}
+			docs = found
+		}
+	}
+	set := make(map[*model.Verse]bool, len(docs))
+	for _, d := range docs {
+		set[ix.Docs[d].Verse] = true
+	}
+	return set
+}
+
+// intersect keeps the sorted values found in both a and b
+func intersect(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// printContext prints a verse shown around a search result, dimmed
+func printContext(book *model.Book, v bookVerse) {
+	fmt.Printf("%s[%s %d:%d] %v%s\n", ui.ColorGray, book.Name, v.Chapter, v.Number, v.Text, ui.ColorReset)
+}
+
+// matcher finds a grep query in verse text
+type matcher struct {
+	re     *regexp.Regexp
+	query  *query.Query    // Set instead of re for a query with operators ("grace AND faith")
+	terms  map[string]bool // Words of the query, highlighted in results
+	stem   search.Stemmer  // Set with -s: query and verse words are compared by stem
+	words  bool            // Matches must not touch a letter or digit on either side
+	invert bool
+}
+
+// isQuery reports whether a search is in the query language. A single quoted word is not: it is
+// looked for like unquoted text, inside longer words too unless -w.
+func isQuery(text string) bool {
+	if inner, ok := strings.CutPrefix(strings.TrimSpace(text), `"`); ok {
+		if inner, ok = strings.CutSuffix(inner, `"`); ok && !strings.Contains(inner, `"`) && len(strings.Fields(inner)) == 1 {
+			return false
+		}
+	}
+	return query.HasOperators(text)
+}
+
+// newMatcher compiles a query: one with operators in the query language, any other as literal
+// text unless -E, ignoring case unless -c. Given a stemmer, plain text is a phrase of words
+// matched by stem.
+func newMatcher(text string, opts map[string]string, stem search.Stemmer) (*matcher, error) {
+	_, regex := opts["-E"]
+	_, caseSensitive := opts["-c"]
+	_, words := opts["-w"]
+	_, invert := opts["-v"]
+
+	if !regex && (isQuery(text) || stem != nil) {
+		if !isQuery(text) {
+			text = `"` + strings.Trim(text, `" `) + `"`
+		}
+		q, err := query.Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		if stem != nil {
+			q = q.MapWords(stem.Stem)
+		}
+		m := &matcher{query: q, terms: make(map[string]bool), stem: stem, invert: invert}
+		for _, w := range q.Terms() {
+			m.terms[w] = true
+		}
+		return m, nil
+	}
+
+	text = strings.ReplaceAll(text, "\"", "")
+	pattern := text
+	if !regex {
+		pattern = regexp.QuoteMeta(text)
+	}
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &matcher{re: re, words: words, invert: invert}, nil
+}
+
+func (m *matcher) matches(text string) bool {
+	if m.query != nil {
+		var words []string
+		for _, tok := range search.Tokenize(text) {
+			words = append(words, m.word(tok))
+		}
+		return m.query.Match(words) != m.invert
+	}
+	return (len(m.find(text)) > 0) != m.invert
+}
+
+// find returns where the query occurs in text. Regexp's \b only knows ASCII letters, so whole
+// words are checked here against any letter: -w "aim" must not match "aimé".
+func (m *matcher) find(text string) [][]int {
+	var found [][]int
+	if m.re == nil {
+		for _, tok := range search.Tokenize(text) {
+			if m.terms[m.word(tok)] {
+				found = append(found, []int{tok.Start, tok.End})
+			}
+		}
+		return found
+	}
+	for _, loc := range m.re.FindAllStringIndex(text, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		if m.words && (isWordRune(lastRune(text[:loc[0]])) || isWordRune(firstRune(text[loc[1]:]))) {
+			continue
+		}
+		found = append(found, loc)
+	}
+	return found
+}
+
+// word is how a verse word is compared with the words of the query: by stem with -s
+func (m *matcher) word(tok search.Token) string {
+	if m.stem != nil {
+		return m.stem.Stem(tok.Word)
+	}
+	return tok.Word
+}
+
+// highlight colors every occurrence of the query; inverted results have none to color
+func (m *matcher) highlight(text string) string {
+	if m.invert {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range m.find(text) {
+		b.WriteString(text[last:loc[0]])
+		b.WriteString(ui.ColorRed + text[loc[0]:loc[1]] + ui.ColorReset)
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
+
+// merge returns a copy of a with the entries of b added
+func merge(a, b map[string]bool) map[string]bool {
+	out := maps.Clone(a)
+	maps.Copy(out, b)
+	return out
+}
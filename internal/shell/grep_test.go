@@ -0,0 +1,187 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/testutils"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/ui"
+)
+
+func TestGrepFlags(t *testing.T) {
+	db := model.NewRegistry(mustParse(`{"meta": {"id": "kjv"}, "NT": {
+		"John": {"3": {"16": "For God so loved the world", "35": "The Father loveth the Son"}},
+		"1 John": {"4": {"8": "He that loveth not knoweth not God; for God is love.", "18": "There is no fear in love"}},
+		"James": {"2": {"24": "Ye see then how that by works a man is justified, and not by faith only."}},
+		"Romans": {"3": {"28": "Therefore we conclude that a man is justified by faith without the deeds of the law."}}}}`))
+	engine := New(db)
+
+	tests := []struct {
+		command string
+		want    []string // Verses found
+	}{
+		{"grep love", []string{"John 3:16", "John 3:35", "1 John 4:8", "1 John 4:18"}},
+		{"grep -w love", []string{"1 John 4:8", "1 John 4:18"}},
+		{"grep -c God", []string{"John 3:16", "1 John 4:8"}},
+		{"grep -c god", nil},
+		{"grep -E faith.*works", nil},
+		{"grep -E works.*faith", []string{"James 2:24"}},
+		{"grep -E \\bfaith\\b", []string{"Romans 3:28", "James 2:24"}},
+		{"grep -v -w love", []string{"John 3:16", "John 3:35", "Romans 3:28", "James 2:24"}},
+		{"grep -w -E justif(y|ied)", []string{"Romans 3:28", "James 2:24"}},
+		{"grep faith AND works", []string{"James 2:24"}},
+		{"grep justified -works", []string{"Romans 3:28"}},
+		{`grep "is love"`, []string{"1 John 4:8"}},
+		{`grep "love"`, []string{"John 3:16", "John 3:35", "1 John 4:8", "1 John 4:18"}}, // Like unquoted text
+		{`grep -w "love"`, []string{"1 John 4:8", "1 John 4:18"}},
+		{"grep (love OR loveth) NOT fear", []string{"John 3:35", "1 John 4:8"}},
+		{"grep faith NEAR/3 justified", []string{"Romans 3:28"}},
+		{"grep -v (God OR faith)", []string{"John 3:35", "1 John 4:18"}},
+		{"grep NOT God", []string{"John 3:35", "Romans 3:28", "James 2:24", "1 John 4:18"}},
+		{"grep --rank God love", []string{"John 3:16", "1 John 4:8", "John 3:35", "1 John 4:18"}},
+		{"grep --rank -w God love", []string{"1 John 4:8", "John 3:16", "1 John 4:18"}},
+		{"grep --rank justif", []string{"Romans 3:28", "James 2:24"}}, // Inside longer words, as without --rank
+		{"grep --rank -c god", nil},
+		{"grep --rank justified by faith", []string{"Romans 3:28", "James 2:24"}}, // In the order asked
+		{`grep --rank "by faith" OR works`, []string{"James 2:24", "Romans 3:28"}},
+		{"grep --rank --limit 1 God love", []string{"John 3:16"}},
+		{"grep --limit 2 love", []string{"John 3:16", "John 3:35"}},
+		{"grep loveth", []string{"John 3:35", "1 John 4:8"}},
+		{"grep -s loveth", []string{"John 3:16", "John 3:35", "1 John 4:8", "1 John 4:18"}},
+		{"grep -s justify", []string{"Romans 3:28", "James 2:24"}},
+		{"grep -s -v loving", []string{"Romans 3:28", "James 2:24"}},
+		{"grep -s loved AND fear", []string{"1 John 4:18"}},
+		{"grep -s loving the son", []string{"John 3:35"}},
+		{"grep -s --rank loving God", []string{"1 John 4:8", "John 3:16", "John 3:35", "1 John 4:18"}},
+		{`grep --in "rom-jas" faith`, []string{"Romans 3:28", "James 2:24"}},
+		{"grep --in gospels love", []string{"John 3:16", "John 3:35"}},
+		{`grep --in "1 john 4:18" love`, []string{"1 John 4:18"}},
+		{`grep --in "pauline epistles" --rank justified`, []string{"Romans 3:28"}},
+		{"grep --in nt --count love", nil},
+	}
+	for _, tt := range tests {
+		output := testutils.CaptureOutput(func() { engine.RunCommand(tt.command) })
+		var got []string
+		for line := range strings.SplitSeq(output, "\n") {
+			if _, rest, ok := strings.Cut(line, ui.ColorCyan+"["); ok {
+				ref, _, _ := strings.Cut(rest, "]")
+				got = append(got, ref)
+			}
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: found %v, want %v", tt.command, got, tt.want)
+		}
+	}
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("grep God") })
+	if strings.Count(output, "\x1b[31mGod\x1b[0m") != 3 {
+		t.Errorf("Every occurrence should be highlighted, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep --count -w love") })
+	if strings.Contains(output, "[1 John") || !strings.Contains(output, "Found 2 matches.") {
+		t.Errorf("--count should only count, got:\n%s", output)
+	}
+
+	// Searches narrowed by the word index find what a full scan finds
+	for _, query := range []string{"lov", "God so", "by faith", "is justified", "n", "the law."} {
+		m, _ := newMatcher(query, nil, nil)
+		want := 0
+		for _, bk := range db.Bibles[0].Books() {
+			for _, v := range bookVerses(bk) {
+				if m.matches(v.Text) {
+					want++
+				}
+			}
+		}
+		output := testutils.CaptureOutput(func() { engine.RunCommand("grep --count " + query) })
+		if !strings.Contains(output, fmt.Sprintf("Found %d matches.", want)) && !(want == 0 && strings.Contains(output, "No matches.")) {
+			t.Errorf("grep %s: expected %d matches, got:\n%s", query, want, output)
+		}
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep faith AND works") })
+	if !strings.Contains(output, "by \x1b[31mworks\x1b[0m a man") || !strings.Contains(output, "by \x1b[31mfaith\x1b[0m only") {
+		t.Errorf("Query words should be highlighted, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep -s loveth") })
+	if !strings.Contains(output, "so \x1b[31mloved\x1b[0m the") {
+		t.Errorf("Every form of the word should be highlighted, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep (peace OR rest") })
+	if !strings.Contains(output, `Invalid query '(peace OR rest': expected ")" at position 15`) {
+		t.Errorf("A bad query should be reported, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep --rank --limit 1 God love") })
+	if !strings.Contains(output, "Showing 1 of 4 matches") {
+		t.Errorf("A limited search should say how many matches it left out, got:\n%s", output)
+	}
+	for _, command := range []string{"grep --rank -v love", "grep --limit 0 love", "grep -s -E lov.*",
+		"grep -c God AND love", `grep -w "is love"`, "grep -s -c Love"} {
+		output = testutils.CaptureOutput(func() { engine.RunCommand(command) })
+		if !strings.Contains(output, ui.ColorRed) || strings.Contains(output, "Searching") {
+			t.Errorf("%s: expected an error, got:\n%s", command, output)
+		}
+	}
+
+	// An explicit scope replaces the current one
+	engine.RunCommand("cd /NT/John")
+	output = testutils.CaptureOutput(func() { engine.RunCommand(`grep --in "1 john" love`) })
+	if !strings.Contains(output, "Searching for 'love' in 1 john...") || strings.Contains(output, "[John 3") || !strings.Contains(output, "Found 2 matches.") {
+		t.Errorf("--in should search 1 John from John, got:\n%s", output)
+	}
+	engine.RunCommand("cd /")
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep --in psalms love") })
+	if !strings.Contains(output, "Unknown scope 'psalms'") {
+		t.Errorf("A scope outside the translation should be reported, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep -E (grace") })
+	if !strings.Contains(output, "Invalid pattern '(grace'") {
+		t.Errorf("A bad regular expression should be reported, got:\n%s", output)
+	}
+}
+
+func TestGrepStemming(t *testing.T) {
+	db := model.NewRegistry(mustParse(`{"meta": {"id": "lsg", "language": "fr"}, "NT": {
+		"John": {"3": {"16": "Car Dieu a tant aimé le monde", "35": "Le Père aime le Fils", "36": "Celui qui croit au Fils a la vie éternelle"}}}}`))
+	engine := New(db)
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("grep -s aimer") })
+	if !strings.Contains(output, "[John 3:16]") || !strings.Contains(output, "[John 3:35]") || strings.Contains(output, "[John 3:36]") {
+		t.Errorf("A French translation should be searched with the French stemmer, got:\n%s", output)
+	}
+
+	db = model.NewRegistry(mustParse(`{"meta": {"id": "vulgate", "language": "la"}, "NT": {
+		"John": {"3": {"16": "sic enim dilexit Deus mundum", "17": "non enim misit Deus Filium suum in mundum"}}}}`))
+	engine = New(db)
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grep -s mundum") })
+	if !strings.Contains(output, "No stemmer for the language of vulgate") || !strings.Contains(output, "Found 2 matches.") {
+		t.Errorf("Without a stemmer, -s should say so and match whole words, got:\n%s", output)
+	}
+}
+
+func TestWordsCommand(t *testing.T) {
+	engine := New(getMockDB())
+	if engine.indexes["kjv"] == nil {
+		t.Error("Loading a translation should build its word index")
+	}
+	engine.RunCommand("use web")
+	if engine.indexes["web"] == nil {
+		t.Error("Switching translation should build its word index")
+	}
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("words Wor") })
+	if !strings.Contains(output, "world") || !strings.Contains(output, "1 verses") {
+		t.Errorf("Expected the indexed words starting with 'wor', got:\n%s", output)
+	}
+	output = testutils.CaptureOutput(func() { engine.RunCommand("words zz") })
+	if !strings.Contains(output, "No words starting with 'zz' in WEB") {
+		t.Errorf("Expected no words, got:\n%s", output)
+	}
+}
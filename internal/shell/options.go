@@ -0,0 +1,164 @@
+package shell
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseOptions strips leading flags off a command's arguments.
+// spec maps each known flag (e.g. "--versions", "-C") to whether it takes a value.
+// Parsing stops at the first token that is not a known flag, so query text such as
+// "-hate" is left untouched. The remainder is returned verbatim, quotes included.
+func parseOptions(args string, spec map[string]bool) (map[string]string, string, error) {
+	opts := make(map[string]string)
+	rest := strings.TrimSpace(args)
+
+	for rest != "" {
+		token, after := nextToken(rest)
+		name, value, hasValue := strings.Cut(token, "=")
+
+		takesValue, known := spec[name]
+		if !known || !strings.HasPrefix(name, "-") {
+			break
+		}
+
+		if takesValue && !hasValue {
+			if after == "" {
+				return nil, "", fmt.Errorf("option %s needs a value", name)
+			}
+			value, after = nextToken(after)
+		}
+		opts[name] = unquote(value)
+		rest = after
+	}
+	return opts, rest, nil
+}
+
+// nextToken returns the first whitespace-separated token of s (a quoted token may contain spaces)
+// and the trimmed remainder.
+func nextToken(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", ""
+	}
+	if s[0] == '"' {
+		if end := strings.IndexByte(s[1:], '"'); end >= 0 {
+			return s[:end+2], strings.TrimSpace(s[end+2:])
+		}
+	}
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+	return s, ""
+}
+
+// commandFlags are the options of each command that takes some, by command name
+var commandFlags = map[string]map[string]bool{
+	"grep": grepFlags, "search": grepFlags,
+	"cat": catFlags, "read": catFlags,
+	"parallel": parallelFlags, "compare": parallelFlags,
+	"export": exportFlags,
+}
+
+// JoinArgs turns the arguments of the program into a command line. The shell that ran it has
+// already split them, so an argument with spaces was quoted there: it stays one option value
+// (--in "1 john"), or one phrase among several words of a search, but a lone argument after
+// the options is the whole search or reference, as if typed at the prompt.
+func JoinArgs(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	spec := commandFlags[strings.ToLower(args[0])]
+	line := []string{args[0]}
+	i := 1
+	for ; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		takesValue, known := spec[name]
+		if !known {
+			break
+		}
+		switch {
+		case hasValue:
+			line = append(line, name+"="+quoteSpaces(value))
+		case takesValue && i+1 < len(args):
+			line = append(line, name, quoteSpaces(args[i+1]))
+			i++
+		default:
+			line = append(line, name)
+		}
+	}
+	rest := args[i:]
+	for _, arg := range rest {
+		if len(rest) > 1 {
+			arg = quoteSpaces(arg)
+		}
+		line = append(line, arg)
+	}
+	return strings.Join(line, " ")
+}
+
+// quoteSpaces quotes an argument that holds spaces, so that it stays one.
+func quoteSpaces(arg string) string {
+	if strings.ContainsAny(arg, " \t") {
+		return `"` + arg + `"`
+	}
+	return arg
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// splitArgs splits arguments on whitespace, keeping quoted paths together.
+func splitArgs(args string) []string {
+	var tokens []string
+	for rest := strings.TrimSpace(args); rest != ""; {
+		var token string
+		token, rest = nextToken(rest)
+		tokens = append(tokens, unquote(token))
+	}
+	return tokens
+}
+
+// contextFlags are the grep-style options that show verses around the ones read or found
+var contextFlags = map[string]bool{
+	"-C": true,
+	"-A": true,
+	"-B": true,
+}
+
+// window is how many verses to show, dimmed, before and after the verses asked for
+type window struct {
+	before, after int
+}
+
+// contextWindow reads -C (both sides), -B (before) and -A (after); nil if none was given.
+func contextWindow(opts map[string]string) (*window, error) {
+	var w *window
+	for _, name := range []string{"-C", "-B", "-A"} {
+		value, ok := opts[name]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("option %s needs a number of verses, got %q", name, value)
+		}
+		if w == nil {
+			w = &window{}
+		}
+		switch name {
+		case "-C":
+			w.before, w.after = n, n
+		case "-B":
+			w.before = n
+		case "-A":
+			w.after = n
+		}
+	}
+	return w, nil
+}
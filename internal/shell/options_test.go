@@ -0,0 +1,23 @@
+package shell
+
+import "testing"
+
+func TestJoinArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"grep", "--rank", "shepherd want"}, "grep --rank shepherd want"},
+		{[]string{"grep", "--rank", "shepherd", "want"}, "grep --rank shepherd want"},
+		{[]string{"grep", "living water", "NOT", "well"}, `grep "living water" NOT well`},
+		{[]string{"grep", "--in", "1 john", "love"}, `grep --in "1 john" love`},
+		{[]string{"grep", "--in=minor prophets", "day"}, `grep --in="minor prophets" day`},
+		{[]string{"john 3:16"}, "john 3:16"},
+		{[]string{"cat", "-C", "1", "john 3:16"}, "cat -C 1 john 3:16"},
+	}
+	for _, tt := range tests {
+		if got := JoinArgs(tt.args); got != tt.want {
+			t.Errorf("JoinArgs(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package shell
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/reference"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/ui"
+)
+
+// parallelFlags are the options accepted by 'parallel' and 'cat --versions'
+var parallelFlags = map[string]bool{
+	"--versions": true,
+	"--columns":  false,
+	"--stacked":  false,
+}
+
+// catFlags are the options accepted by 'cat': a context window, or other translations beside it
+var catFlags = merge(contextFlags, parallelFlags)
+
+// minColumnWidth is the narrowest column before the layout falls back to stacked
+const minColumnWidth = 24
+
+// --- PARALLEL READING ---
+
+// doParallel prints one reference once per translation, aligned verse by verse. Translations with
+// another versification show the verse that corresponds, with its own number in brackets.
+func (e *Engine) doParallel(args string) {
+	opts, ref, err := parseOptions(args, catFlags)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", ui.ColorRed, err, ui.ColorReset)
+		return
+	}
+	if w, err := contextWindow(opts); err != nil || w != nil {
+		fmt.Printf("%sError: -C, -A and -B cannot be combined with parallel reading.%s\n", ui.ColorRed, ui.ColorReset)
+		return
+	}
+	bibles, err := e.pickVersions(opts["--versions"])
+	if err != nil {
+		fmt.Printf("%s%v%s\n", ui.ColorRed, err, ui.ColorReset)
+		return
+	}
+
+	if ref == "" {
+		fmt.Printf("%sError: Give a reference (e.g. 'parallel john 3:16').%s\n", ui.ColorRed, ui.ColorReset)
+		return
+	}
+	ranges, err := e.parseRef(ref)
+	if err == nil {
+		ranges, err = e.splitBooks(ranges)
+	}
+	if err != nil {
+		e.printRefError(ref, err)
+		return
+	}
+
+	// One row per verse, one cell per translation. Verses are listed as the active translation
+	// has each chapter (or as any of the others do), numbered as in the active translation and
+	// mapped into each translation's own versification.
+	var rows []parallelRow
+	for _, r := range ranges {
+		book := e.bible().Book(r.Start.Book)
+		if book == nil {
+			fmt.Printf("%sBook %s not found.%s\n", ui.ColorRed, r.Start.Book, ui.ColorReset)
+			return
+		}
+		if r.Start.Chapter == 0 {
+			fmt.Printf("%sError: Give a chapter (e.g. 'parallel john 3:16').%s\n", ui.ColorRed, ui.ColorReset)
+			return
+		}
+		r = settle(book, r)
+		found := e.parallelRows(bibles, book, r)
+		if len(found) == 0 {
+			fmt.Printf("%sNo verses found for %s.%s\n", ui.ColorRed, reference.Format([]reference.Range{r}), ui.ColorReset)
+			return
+		}
+		rows = append(rows, found...)
+	}
+	e.follow(ranges) // Only once the whole reference is found
+
+	ids := make([]string, len(bibles))
+	for i, b := range bibles {
+		ids[i] = strings.ToUpper(b.ID)
+	}
+	fmt.Printf("\n%sReading %s (%s)%s\n", ui.ColorCyan, reference.Format(ranges), strings.Join(ids, " | "), ui.ColorReset)
+
+	width := ui.TerminalWidth()
+	colWidth := (width - 5 - (len(ids)-1)*3) / len(ids)
+	useColumns := colWidth >= minColumnWidth
+	if _, ok := opts["--columns"]; ok {
+		useColumns = true
+	}
+	if _, ok := opts["--stacked"]; ok {
+		useColumns = false
+	}
+
+	if useColumns {
+		renderColumns(ids, rows, max(colWidth, 10))
+	} else {
+		renderStacked(ids, rows)
+	}
+	fmt.Println()
+}
+
+// parallelRow is one verse read in parallel: its place, and its text in each translation ("" if
+// the translation does not have it)
+type parallelRow struct {
+	book           *model.Book
+	chapter, verse int
+	cells          []string
+}
+
+// parallelRows reads the verses of a range, which lies in one book of the active translation,
+// in each of the translations. Verses none of them has are left out.
+func (e *Engine) parallelRows(bibles []*model.Bible, book *model.Book, r reference.Range) []parallelRow {
+	bookID := book.OSIS
+	if bookID == "" {
+		bookID = book.Name
+	}
+	var rows []parallelRow
+	for c := r.Start.Chapter; c <= r.End.Chapter; c++ {
+		chapters := []*model.Chapter{book.Chapter(c)}
+		if chapters[0] == nil {
+			chapters = make([]*model.Chapter, len(bibles))
+			for i, b := range bibles {
+				if bk := b.Book(book.Name); bk != nil {
+					chapters[i] = bk.Chapter(c)
+				}
+			}
+		}
+		for _, vNum := range chapterVerses(chapters) {
+			if !r.Contains(reference.Ref{Book: book.Name, Chapter: c, Verse: vNum}) {
+				continue
+			}
+			ref := model.VerseRef{Book: bookID, Chapter: c, Verse: vNum}
+			row := parallelRow{book: book, chapter: c, verse: vNum, cells: make([]string, len(bibles))}
+			found := false
+			for i, b := range bibles {
+				mapped, ok := model.MapVerse(ref, e.bible().Scheme(), b.Scheme())
+				if !ok {
+					continue
+				}
+				if v := b.Verse(mapped); v != nil {
+					row.cells[i] = v.Text
+					if mapped != ref {
+						row.cells[i] = fmt.Sprintf("(%d:%d) %s", mapped.Chapter, mapped.Verse, v.Text)
+					}
+					found = true
+				}
+			}
+			if found {
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows
+}
+
+// pickVersions turns "kjv,web" into translations; empty means all, active first
+func (e *Engine) pickVersions(list string) ([]*model.Bible, error) {
+	if list == "" {
+		bibles := []*model.Bible{e.bible()}
+		for _, b := range e.DB.Bibles {
+			if b != e.bible() {
+				bibles = append(bibles, b)
+			}
+		}
+		return bibles, nil
+	}
+
+	var bibles []*model.Bible
+	for id := range strings.SplitSeq(list, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		b := e.DB.Get(id)
+		if b == nil {
+			return nil, fmt.Errorf("translation '%s' not loaded. Available: %s", id, strings.Join(e.DB.IDs(), ", "))
+		}
+		bibles = append(bibles, b)
+	}
+	if len(bibles) == 0 {
+		return nil, fmt.Errorf("no translations given")
+	}
+	return bibles, nil
+}
+
+// chapterVerses lists the verse numbers found in any of the chapters, in order of appearance
+func chapterVerses(chapters []*model.Chapter) []int {
+	seen := make(map[int]bool)
+	var all []int
+	for _, ch := range chapters {
+		if ch == nil {
+			continue
+		}
+		for _, v := range ch.Verses {
+			if !seen[v.Number] {
+				seen[v.Number] = true
+				all = append(all, v.Number)
+			}
+		}
+	}
+	return all
+}
+
+// missingVerse stands in for a verse a translation does not have
+const missingVerse = "(not in this translation)"
+
+// chapterBreak marks where the rows move into another chapter or book, as a passage read with
+// cat does
+func chapterBreak(rows []parallelRow, r int) {
+	if r == 0 || rows[r].book == rows[r-1].book && rows[r].chapter == rows[r-1].chapter {
+		return
+	}
+	label := "Chapter " + strconv.Itoa(rows[r].chapter)
+	if rows[r].book != rows[r-1].book {
+		label = fmt.Sprintf("%s %d", rows[r].book.Name, rows[r].chapter)
+	}
+	fmt.Println(ui.ColorGray + "── " + label + " ──" + ui.ColorReset)
+}
+
+func renderStacked(ids []string, rows []parallelRow) {
+	labelWidth := 0
+	for _, id := range ids {
+		labelWidth = max(labelWidth, len(id))
+	}
+	for r, row := range rows {
+		chapterBreak(rows, r)
+		for i, text := range row.cells {
+			prefix := "     "
+			if i == 0 {
+				prefix = fmt.Sprintf("%3d: ", row.verse)
+			}
+			if text == "" {
+				text = ui.ColorGray + missingVerse + ui.ColorReset
+			}
+			fmt.Printf("%s%s%s%s%s  %s\n", ui.ColorYellow, prefix, ui.ColorGreen, ui.Pad(ids[i], labelWidth), ui.ColorReset, text)
+		}
+	}
+}
+
+func renderColumns(ids []string, rows []parallelRow, colWidth int) {
+	sep := ui.ColorGray + " │ " + ui.ColorReset
+	headers := make([]string, len(ids))
+	for i, id := range ids {
+		headers[i] = ui.ColorGreen + ui.Pad(id, colWidth) + ui.ColorReset
+	}
+	fmt.Println("     " + strings.Join(headers, sep))
+
+	for r, row := range rows {
+		chapterBreak(rows, r)
+		wrapped := make([][]string, len(row.cells))
+		height := 0
+		for i, text := range row.cells {
+			if text == "" {
+				text = missingVerse
+			}
+			wrapped[i] = ui.Wrap(text, colWidth)
+			height = max(height, len(wrapped[i]))
+		}
+		for line := 0; line < height; line++ {
+			prefix := "     "
+			if line == 0 {
+				prefix = fmt.Sprintf("%3d: ", row.verse)
+			}
+			cells := make([]string, len(row.cells))
+			for i := range row.cells {
+				cell := ""
+				if line < len(wrapped[i]) {
+					cell = wrapped[i][line]
+				}
+				cells[i] = ui.Pad(cell, colWidth)
+				if row.cells[i] == "" {
+					cells[i] = ui.ColorGray + cells[i] + ui.ColorReset
+				}
+			}
+			fmt.Printf("%s%s%s%s\n", ui.ColorYellow, prefix, ui.ColorReset, strings.Join(cells, sep))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package shell
+
+import (
+	"slices"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+)
+
+// commands lists the names RunCommand answers to, for suggestions
+var commands = []string{
+	"exit", "quit", "ls", "ll", "cd", "cat", "read", "parallel", "compare", "grep", "search", "words",
+	"mark", "goto", "jump", "marks", "manna", "random", "version", "versions", "use",
+	"import", "export", "validate", "next", "prev", "set", "help", "clear", "cls",
+}
+
+// --- SUGGESTIONS ---
+
+// suggestBooks lists the books of the active translation whose names are closest to a misspelt
+// name ("genisis", "1 jhon"), in reading order
+func (e *Engine) suggestBooks(name string) []string {
+	books := closest(model.NormalizeName(name), e.Spellings)
+	order := make(map[string]int)
+	for i, bk := range e.bible().Books() {
+		order[bk.Name] = i
+	}
+	sort.Slice(books, func(i, j int) bool { return order[books[i]] < order[books[j]] })
+	return books
+}
+
+// suggestCommands lists the commands closest to a mistyped one
+func suggestCommands(cmd string) []string {
+	names := make(map[string]string, len(commands))
+	for _, c := range commands {
+		names[c] = c
+	}
+	found := closest(cmd, names)
+	sort.Strings(found)
+	return found
+}
+
+// closest returns the values of the spellings nearest to word, if they are near enough to be a
+// typo: one edit for short words, two for longer ones. Words under three letters match nothing.
+func closest(word string, spellings map[string]string) []string {
+	n := utf8.RuneCountInString(word)
+	if n < 3 {
+		return nil
+	}
+	limit := 1
+	if n > 4 {
+		limit = 2
+	}
+
+	var found []string
+	for spelling, value := range spellings {
+		d := editDistance(word, spelling)
+		if d > limit {
+			continue
+		}
+		if d < limit {
+			limit, found = d, nil
+		}
+		if !slices.Contains(found, value) {
+			found = append(found, value)
+		}
+	}
+	return found
+}
+
+// editDistance counts the insertions, deletions, substitutions and swaps of adjacent letters
+// that turn a into b ("grpe" is one away from "grep")
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
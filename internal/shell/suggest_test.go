@@ -0,0 +1,57 @@
+package shell
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/testutils"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"grep", "grep", 0},
+		{"grpe", "grep", 1},
+		{"genisis", "genesis", 1},
+		{"phillipians", "philippians", 2},
+		{"", "cat", 3},
+		{"génesis", "genesis", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestTypoSuggestions(t *testing.T) {
+	engine := New(getMockDB())
+
+	output := testutils.CaptureOutput(func() { engine.RunCommand("cd genisis") })
+	if engine.GetPathString() != "/OT/Genesis" || !strings.Contains(output, "Assuming 'genisis' means Genesis.") {
+		t.Errorf("A single close book should be corrected, got %s:\n%s", engine.GetPathString(), output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cat jhon 3:16") })
+	if !strings.Contains(output, "For God so loved...") {
+		t.Errorf("A misspelt book should be read, got:\n%s", output)
+	}
+
+	// "2john" is one edit from both "John" and "1 John", and this translation has no 2 John
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cd 2john") })
+	if !strings.Contains(output, "Did you mean: John, 1 John?") || engine.GetPathString() != "/NT/John" {
+		t.Errorf("Several close books should be listed, got %s:\n%s", engine.GetPathString(), output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("cd xyzzy") })
+	if !strings.Contains(output, "Path 'xyzzy' not found.") || strings.Contains(output, "Did you mean") {
+		t.Errorf("Nothing close should stay not found, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() { engine.RunCommand("grpe love") })
+	if !strings.Contains(output, "Command 'grpe' not found.") || !strings.Contains(output, "Did you mean: grep?") {
+		t.Errorf("A mistyped command should suggest the right one, got:\n%s", output)
+	}
+}
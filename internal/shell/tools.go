@@ -0,0 +1,266 @@
+package shell
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/reference"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/ui"
+)
+
+// --- IMPORT ---
+
+// doImport converts a source translation into the JSON layout:
+// import [format] <path> [-o out.json]. Without a format it is guessed from the file extension.
+func (e *Engine) doImport(args string) {
+	var positional []string
+	output, format := "", ""
+	tokens := splitArgs(args)
+	for i := 0; i < len(tokens); i++ {
+		if flag := tokens[i]; flag == "-o" || flag == "--output" || flag == "--format" {
+			if i+1 == len(tokens) {
+				fmt.Printf("%sOption %s needs a value.%s\n", ui.ColorRed, flag, ui.ColorReset)
+				return
+			}
+			if flag == "--format" {
+				format = tokens[i+1]
+			} else {
+				output = tokens[i+1]
+			}
+			i++
+			continue
+		}
+		positional = append(positional, tokens[i])
+	}
+	if len(positional) == 2 && format == "" {
+		format, positional = positional[0], positional[1:]
+	}
+	if len(positional) != 1 || (format == "" && slices.Contains(model.Formats, strings.ToLower(positional[0]))) {
+		fmt.Printf("Usage: import [%s] <path> [-o out.json]\n", strings.Join(model.Formats, "|"))
+		return
+	}
+
+	source := positional[0]
+	db, err := importSource(source, strings.ToLower(format))
+	if err != nil {
+		fmt.Printf("%sImport failed: %v%s\n", ui.ColorRed, err, ui.ColorReset)
+		return
+	}
+
+	if output == "" {
+		output = db.ID + ".json"
+	}
+	data, err := json.Marshal(db)
+	if err == nil {
+		err = os.WriteFile(output, data, 0644)
+	}
+	if err != nil {
+		fmt.Printf("%sCould not write %s: %v%s\n", ui.ColorRed, output, err, ui.ColorReset)
+		return
+	}
+	fmt.Printf("%sImported %d books into %s%s\n", ui.ColorGreen, len(db.Books()), output, ui.ColorReset)
+}
+
+// importSource reads a translation for import. USFM may be a directory of books.
+func importSource(source, format string) (*model.Bible, error) {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		if format != "" && format != model.FormatUSFM {
+			return nil, fmt.Errorf("%s is a directory; only usfm imports a directory", source)
+		}
+		return model.LoadUSFMDir(source)
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		if format = model.DetectFormat(source, data); format == "" {
+			return nil, fmt.Errorf("cannot tell the format of %s; give one of: %s", filepath.Base(source), strings.Join(model.Formats, ", "))
+		}
+	}
+	db, err := model.Parse(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if db.ID == "" {
+		db.ID = strings.ToLower(strings.TrimSuffix(filepath.Base(source), filepath.Ext(source)))
+	}
+	return db, nil
+}
+
+// --- EXPORT ---
+
+// exportFlags are the options accepted by 'export'
+var exportFlags = map[string]bool{
+	"--format": true,
+	"--scope":  true,
+	"-o":       true,
+	"--output": true,
+}
+
+// doExport writes the active translation, or the part of it named by --scope, to disk:
+// export --format <fmt> [--scope <ref>] [-o out]. "-o -" prints to the terminal.
+func (e *Engine) doExport(args string) {
+	opts, rest, err := parseOptions(args, exportFlags)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", ui.ColorRed, err, ui.ColorReset)
+		return
+	}
+	format := strings.ToLower(opts["--format"])
+	if format == "" {
+		fmt.Printf("Usage: export --format <%s> [--scope <ref>] [-o out]\n", strings.Join(model.ExportFormats, "|"))
+		return
+	}
+	if !slices.Contains(model.ExportFormats, format) {
+		fmt.Printf("%sUnknown export format '%s'. Supported: %s%s\n", ui.ColorRed, format, strings.Join(model.ExportFormats, ", "), ui.ColorReset)
+		return
+	}
+
+	scope := opts["--scope"]
+	if scope == "" {
+		scope = unquote(rest)
+	}
+	db, err := e.scopeBible(scope)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", ui.ColorRed, err, ui.ColorReset)
+		return
+	}
+
+	output := opts["-o"]
+	if output == "" {
+		output = opts["--output"]
+	}
+	if output == "" {
+		output = db.ID + model.ExportExtension(format)
+		if format == model.FormatUSFM {
+			output = db.ID + "-usfm"
+		}
+	}
+
+	switch {
+	case format == model.FormatUSFM:
+		err = model.ExportUSFM(output, db)
+	case output == "-":
+		err = model.Export(os.Stdout, db, format)
+	default:
+		var f *os.File
+		if f, err = os.Create(output); err == nil {
+			err = model.Export(f, db, format)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	if err != nil {
+		fmt.Printf("%sCould not write %s: %v%s\n", ui.ColorRed, output, err, ui.ColorReset)
+		return
+	}
+	if output != "-" {
+		fmt.Printf("%sExported %d books to %s%s\n", ui.ColorGreen, len(db.Books()), output, ui.ColorReset)
+	}
+}
+
+// scopeBible narrows the active translation to a collection ("nt"), a group of books
+// ("gospels"), books ("rom-gal"), a chapter ("john 3") or verses ("john 3:16-18"). An empty scope
+// keeps everything.
+func (e *Engine) scopeBible(scope string) (*model.Bible, error) {
+	db := e.bible()
+	if scope == "" {
+		return db, nil
+	}
+	if t := db.Collection(scope); t != nil {
+		return db.Filter(func(bk *model.Book, _ *model.Chapter, _ *model.Verse) bool { return bk.Collection == t.ID }), nil
+	}
+	if g, ok := model.LookupGroup(scope); ok {
+		return db.Filter(func(bk *model.Book, _ *model.Chapter, _ *model.Verse) bool { return g.Holds(bk.OSIS) }), nil
+	}
+
+	ranges, err := (&reference.Parser{Books: e.resolveBook, Suggest: e.suggestBooks}).Parse(scope)
+	if err == nil {
+		ranges, err = e.splitBooks(ranges)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unknown scope '%s': %v", scope, err)
+	}
+	for _, r := range ranges {
+		book := db.Book(r.Start.Book)
+		if book == nil {
+			return nil, fmt.Errorf("Book %s not found.", r.Start.Book)
+		}
+		for _, c := range []int{r.Start.Chapter, r.End.Chapter} {
+			if c > 0 && book.Chapter(c) == nil {
+				return nil, fmt.Errorf("Chapter %d not found.", c)
+			}
+		}
+	}
+	return db.Filter(func(bk *model.Book, c *model.Chapter, v *model.Verse) bool {
+		ref := reference.Ref{Book: bk.Name, Chapter: c.Number, Verse: v.Number}
+		for _, r := range ranges {
+			if r.Contains(ref) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// --- VALIDATE ---
+
+// doValidate checks a translation file, or the active translation, and prints an integrity report.
+// Files in other formats are checked as they import, then after conversion to the JSON layout.
+func (e *Engine) doValidate(args string) {
+	label := strings.ToUpper(e.bible().ID)
+	var report *model.Report
+
+	if tokens := splitArgs(args); len(tokens) > 0 {
+		label = tokens[0]
+		data, err := os.ReadFile(label)
+		if err != nil {
+			fmt.Printf("%sCould not read %s: %v%s\n", ui.ColorRed, label, err, ui.ColorReset)
+			return
+		}
+		format := model.DetectFormat(label, data)
+		if format == "" {
+			format = model.FormatJSON
+		}
+		report = model.ValidateFormat(data, format)
+	} else {
+		data, err := json.Marshal(e.bible())
+		if err != nil {
+			fmt.Printf("%sCould not read %s: %v%s\n", ui.ColorRed, label, err, ui.ColorReset)
+			return
+		}
+		report = model.Validate(data)
+	}
+
+	fmt.Printf("\n%sValidating %s%s\n", ui.ColorCyan, label, ui.ColorReset)
+	for _, c := range report.Counts {
+		fmt.Printf("  %s%-3s%s %3d books %5d chapters %6d verses\n", ui.ColorBlue, c.ID, ui.ColorReset, c.Books, c.Chapters, c.Verses)
+	}
+	for _, issue := range report.Issues {
+		mark, color := "!", ui.ColorYellow
+		if issue.Severity == model.SeverityError {
+			mark, color = "✗", ui.ColorRed
+		}
+		where := ""
+		if issue.Where != "" {
+			where = issue.Where + ": "
+		}
+		fmt.Printf("  %s%s %s%s%s\n", color, mark, where, issue.Message, ui.ColorReset)
+	}
+
+	errors, warnings := report.Errors(), report.Warnings()
+	color := ui.ColorGreen
+	if errors > 0 {
+		color = ui.ColorRed
+	} else if warnings > 0 {
+		color = ui.ColorYellow
+	}
+	fmt.Printf("%sResult: %d errors, %d warnings%s\n\n", color, errors, warnings, ui.ColorReset)
+}
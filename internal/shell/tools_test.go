@@ -0,0 +1,131 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EcclesiaTechStudio/bible-cli/internal/model"
+	"github.com/EcclesiaTechStudio/bible-cli/internal/testutils"
+)
+
+func TestImportUSFM(t *testing.T) {
+	engine := New(getMockDB())
+	src := filepath.Join(t.TempDir(), "web")
+	os.Mkdir(src, 0755)
+	os.WriteFile(filepath.Join(src, "JHN.usfm"), []byte("\\id JHN\n\\c 3\n\\s1 Jesus and Nicodemus\n\\p\n\\v 16 For God so loved the world.\n"), 0644)
+	out := filepath.Join(t.TempDir(), "out file.json")
+
+	output := testutils.CaptureOutput(func() {
+		engine.RunCommand(`import usfm ` + src + ` -o "` + out + `"`)
+	})
+	if !strings.Contains(output, "Imported 1 books") {
+		t.Fatalf("Import should report success, got:\n%s", output)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("Output file not written: %v", err)
+	}
+	db, err := model.ParseDatabase(data)
+	if err != nil {
+		t.Fatalf("Imported JSON does not load: %v", err)
+	}
+	if v := db.Book("John").Chapter(3).Verse(16); v.Heading != "Jesus and Nicodemus" {
+		t.Errorf("Heading should survive the import, got %+v", v)
+	}
+
+	output = testutils.CaptureOutput(func() {
+		engine.RunCommand("import usfm")
+	})
+	if !strings.Contains(output, "Usage") {
+		t.Errorf("Missing arguments should print usage, got:\n%s", output)
+	}
+}
+
+func TestImportDetectsFormat(t *testing.T) {
+	engine := New(getMockDB())
+	dir := t.TempDir()
+	src := filepath.Join(dir, "mine.tsv")
+	os.WriteFile(src, []byte("John\t3\t16\tFor God so loved the world\n"), 0644)
+	out := filepath.Join(dir, "mine.json")
+
+	output := testutils.CaptureOutput(func() {
+		engine.RunCommand("import " + src + " -o " + out)
+	})
+	if !strings.Contains(output, "Imported 1 books") {
+		t.Fatalf("Format should be detected from the extension, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() {
+		engine.RunCommand("import --format csv " + src + " -o " + out)
+	})
+	if !strings.Contains(output, "Import failed") {
+		t.Errorf("--format should override the extension, got:\n%s", output)
+	}
+}
+
+func TestExport(t *testing.T) {
+	engine := New(getMockDB())
+	engine.RunCommand("cd john")
+	out := filepath.Join(t.TempDir(), "jn.csv")
+
+	output := testutils.CaptureOutput(func() {
+		engine.RunCommand(`export --format csv --scope "jn 3:16" -o ` + out)
+	})
+	if !strings.Contains(output, "Exported 1 books") {
+		t.Fatalf("Export should report success, got:\n%s", output)
+	}
+	data, _ := os.ReadFile(out)
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], "John,3,16,") {
+		t.Errorf("Scope should narrow the export to one verse, got:\n%s", data)
+	}
+	if engine.GetPathString() != "/NT/John" {
+		t.Errorf("Resolving the scope should not move the shell, got %s", engine.GetPathString())
+	}
+
+	tests := map[string]string{
+		"export":                         "Usage",
+		"export --format docx":           "Unknown export format",
+		"export --format json --scope x": "Unknown scope",
+	}
+	for cmd, want := range tests {
+		output := testutils.CaptureOutput(func() { engine.RunCommand(cmd) })
+		if !strings.Contains(output, want) {
+			t.Errorf("%q should print %q, got:\n%s", cmd, want, output)
+		}
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	engine := New(getMockDB())
+	file := filepath.Join(t.TempDir(), "bad.json")
+	os.WriteFile(file, []byte(`{"NT": {"John": {"3": {"16": "", "18": "x"}}}}`), 0644)
+
+	output := testutils.CaptureOutput(func() {
+		engine.RunCommand("validate " + file)
+	})
+	for _, want := range []string{"NT/John 3:16: empty verse text", "NT/John 3: verse 17 missing", "Result: 1 errors"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Report missing %q, got:\n%s", want, output)
+		}
+	}
+
+	// Other formats are checked as they import: the JSON layout would hide the repeated verse
+	file = filepath.Join(t.TempDir(), "bad.csv")
+	os.WriteFile(file, []byte("John,3,16,a\nJohn,3,16,b\n"), 0644)
+	output = testutils.CaptureOutput(func() {
+		engine.RunCommand("validate " + file)
+	})
+	if !strings.Contains(output, "line 2: John 3:16 is repeated (first on line 1)") || !strings.Contains(output, "Result: 1 errors") {
+		t.Errorf("Repeated CSV row should be reported, got:\n%s", output)
+	}
+
+	output = testutils.CaptureOutput(func() {
+		engine.RunCommand("validate")
+	})
+	if !strings.Contains(output, "Validating KJV") || !strings.Contains(output, "Result: 0 errors") {
+		t.Errorf("Without a file the active translation should be checked, got:\n%s", output)
+	}
+}
@@ -2,8 +2,11 @@ package ui
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // ANSI Colors
@@ -44,3 +47,38 @@ func GetSortedKeys[V any](m map[string]V) []string {
 	})
 	return keys
 }
+
+// TerminalWidth reads $COLUMNS and falls back to 80 columns
+func TerminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 80
+}
+
+// Wrap breaks text into lines of at most width runes, splitting on spaces
+func Wrap(text string, width int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 || width <= 0 {
+		return []string{text}
+	}
+	var lines []string
+	line := words[0]
+	for _, w := range words[1:] {
+		if utf8.RuneCountInString(line)+1+utf8.RuneCountInString(w) > width {
+			lines = append(lines, line)
+			line = w
+			continue
+		}
+		line += " " + w
+	}
+	return append(lines, line)
+}
+
+// Pad right-pads s with spaces to width runes
+func Pad(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
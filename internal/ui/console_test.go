@@ -41,6 +41,28 @@ func TestGetSortedKeys(t *testing.T) {
 	}
 }
 
+func TestWrap(t *testing.T) {
+	got := Wrap("For God so loved the world", 10)
+	expected := []string{"For God so", "loved the", "world"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Wrap() = %q, want %q", got, expected)
+	}
+	if Pad("Jean", 6) != "Jean  " {
+		t.Errorf("Pad() should fill to the requested width")
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "120")
+	if TerminalWidth() != 120 {
+		t.Error("TerminalWidth should honour $COLUMNS")
+	}
+	t.Setenv("COLUMNS", "")
+	if TerminalWidth() != 80 {
+		t.Error("TerminalWidth should fall back to 80")
+	}
+}
+
 func TestVisuals(t *testing.T) {
 	// This ensures the function runs without crashing
 	PrintHeader()
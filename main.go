@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
@@ -12,20 +13,36 @@ import (
 )
 
 func main() {
-	// 1. Load Data
-	db, err := model.LoadDatabase()
+	dataPath := flag.String("data", os.Getenv("BIBLE_CLI_DATA"), "translation file or directory of translations (env: BIBLE_CLI_DATA)")
+	format := flag.String("format", "", "format of the --data files: "+strings.Join(model.Formats, ", ")+" (default: from the file extension)")
+	names := flag.String("names", os.Getenv("BIBLE_CLI_NAMES"), "book-name table or directory of tables (env: BIBLE_CLI_NAMES)")
+	flag.Parse()
+
+	// 1. Load Data (falls back to the embedded copy if the external data is unusable)
+	db, err := model.LoadRegistry(*dataPath, *format)
+	if err != nil && *dataPath != "" {
+		fmt.Printf("%sWarning: could not load %s: %v. Using the embedded Bible.%s\n", ui.ColorRed, *dataPath, err, ui.ColorReset)
+		db, err = model.LoadRegistry("", "")
+	}
 	if err != nil {
 		fmt.Printf("%sCRITICAL: %v%s\n", ui.ColorRed, err, ui.ColorReset)
 		return
 	}
+	for _, err := range db.Skipped {
+		fmt.Printf("%sWarning: skipped %v%s\n", ui.ColorRed, err, ui.ColorReset)
+	}
+	if *names != "" {
+		if err := db.LoadNames(*names); err != nil {
+			fmt.Printf("%sWarning: could not load book names from %s: %v%s\n", ui.ColorRed, *names, err, ui.ColorReset)
+		}
+	}
 
 	// 2. Initialize Engine
 	app := shell.New(db)
 
 	// 3. Command Line Args Mode
-	if len(os.Args) > 1 {
-		fullCommand := strings.Join(os.Args[1:], " ")
-		app.RunCommand(fullCommand)
+	if flag.NArg() > 0 {
+		app.RunCommand(shell.JoinArgs(flag.Args()))
 		return
 	}
 
@@ -35,7 +52,7 @@ func main() {
 
 	for {
 		pathStr := app.GetPathString()
-		fmt.Printf("%s📖 %s%s $ ", ui.ColorBlue, ui.ColorGreen, pathStr)
+		fmt.Printf("%s📖 %s[%s] %s%s $ ", ui.ColorBlue, ui.ColorYellow, app.GetVersion(), ui.ColorGreen, pathStr)
 		fmt.Print(ui.ColorReset)
 
 		if !scanner.Scan() {